@@ -0,0 +1,169 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"fmt"
+	"github.com/sotomskir/jira-cli/credentials"
+	"github.com/sotomskir/jira-cli/jiraApi"
+	"github.com/spf13/viper"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// authConfig returns authentication settings of profile. Flags, ENV variables and top level settings
+// take precedence over settings saved in profile. Secret kept in credential store is not read
+func authConfig(p profile) jiraApi.AuthConfig {
+	setting := func(key string, saved string) string {
+		if value := viper.GetString(key); value != "" {
+			return value
+		}
+		return saved
+	}
+	return jiraApi.AuthConfig{
+		Type:           setting("JIRA_AUTH_TYPE", p.Auth),
+		Username:       setting("JIRA_USER", p.User),
+		Password:       setting("JIRA_PASSWORD", p.Password),
+		Token:          setting("JIRA_TOKEN", p.Token),
+		ConsumerKey:    setting("JIRA_OAUTH_CONSUMER_KEY", p.ConsumerKey),
+		PrivateKeyFile: setting("JIRA_OAUTH_PRIVATE_KEY", p.PrivateKey),
+		AccessToken:    setting("JIRA_OAUTH_ACCESS_TOKEN", p.AccessToken),
+	}
+}
+
+// setAuthConfig replaces authentication settings of profile with config. Only settings used by authentication type are kept
+func setAuthConfig(p *profile, config jiraApi.AuthConfig) {
+	*p = profile{Name: p.Name, Url: p.Url, Auth: config.Type}
+	switch config.Type {
+	case jiraApi.AuthBasic:
+		p.User = config.Username
+		p.Password = config.Password
+	case jiraApi.AuthBearer:
+		p.Token = config.Token
+	case jiraApi.AuthCloudToken:
+		p.User = config.Username
+		p.Token = config.Token
+	case jiraApi.AuthOAuth1:
+		p.ConsumerKey = config.ConsumerKey
+		p.PrivateKey = config.PrivateKeyFile
+		p.AccessToken = config.AccessToken
+	}
+}
+
+// secretKind returns kind of secret used by authentication type, under which it is kept in credential store
+func secretKind(authType string) string {
+	switch strings.ToLower(authType) {
+	case jiraApi.AuthBasic, "":
+		return "password"
+	case jiraApi.AuthBearer, jiraApi.AuthCloudToken:
+		return "token"
+	case jiraApi.AuthOAuth1:
+		return "access-token"
+	}
+	return ""
+}
+
+// secret returns field of secret used by authentication type
+func (p *profile) secret() *string {
+	switch secretKind(p.Auth) {
+	case "password":
+		return &p.Password
+	case "token":
+		return &p.Token
+	case "access-token":
+		return &p.AccessToken
+	}
+	return nil
+}
+
+// secretKey returns key of profile secret in credential store
+func (p *profile) secretKey() credentials.Key {
+	return credentials.Key{Server: p.Url, User: p.User, Kind: secretKind(p.Auth)}
+}
+
+// loadSecret reads secret of config from credential store of profile, unless secret is already set
+func loadSecret(p profile, config *jiraApi.AuthConfig) error {
+	var field *string
+	switch secretKind(config.Type) {
+	case "password":
+		field = &config.Password
+	case "token":
+		field = &config.Token
+	case "access-token":
+		field = &config.AccessToken
+	}
+	if p.Store == "" || field == nil || *field != "" {
+		return nil
+	}
+	store, err := credentialStore(p.Store)
+	if err != nil {
+		return err
+	}
+	value, err := store.Get(p.secretKey())
+	if err != nil {
+		return fmt.Errorf("cannot read %s of profile %s from %s credential store: %w", secretKind(p.Auth), p.Name, store.Name(), err)
+	}
+	*field = value
+	return nil
+}
+
+// storeSecret moves secret of profile to credential store
+func storeSecret(store credentials.Store, p *profile) error {
+	field := p.secret()
+	if field == nil || *field == "" {
+		return nil
+	}
+	if err := store.Set(p.secretKey(), *field); err != nil {
+		return fmt.Errorf("cannot save %s of profile %s in %s credential store: %w", secretKind(p.Auth), p.Name, store.Name(), err)
+	}
+	*field = ""
+	p.Store = store.Name()
+	return nil
+}
+
+// authenticator returns authenticator of active profile created with first request. Secret is read from credential store
+// only by commands calling JIRA API and invalid settings do not break other commands
+func authenticator() jiraApi.Authenticator {
+	return &lazyAuth{load: func() (jiraApi.Authenticator, error) {
+		p, err := activeProfile()
+		if err != nil {
+			return nil, err
+		}
+		config := authConfig(p)
+		if err := loadSecret(p, &config); err != nil {
+			return nil, err
+		}
+		return jiraApi.NewAuthenticator(config)
+	}}
+}
+
+// lazyAuth type is authenticator created when first request is sent
+type lazyAuth struct {
+	once sync.Once
+	load func() (jiraApi.Authenticator, error)
+	auth jiraApi.Authenticator
+	err  error
+}
+
+func (a *lazyAuth) Authenticate(req *http.Request) error {
+	a.once.Do(func() {
+		a.auth, a.err = a.load()
+	})
+	if a.err != nil {
+		return a.err
+	}
+	return a.auth.Authenticate(req)
+}
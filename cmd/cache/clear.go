@@ -0,0 +1,40 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/sotomskir/jira-cli/jiraApi"
+	"github.com/spf13/cobra"
+)
+
+// clearCmd represents the cache clear command
+var clearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached responses",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := jiraApi.DefaultCacheDir()
+		cmdutil.CheckErr(err)
+		cmdutil.CheckErr(jiraApi.NewCache(dir, nil).Clear())
+		logrus.Infof("Cache cleared: %s\n", dir)
+	},
+}
+
+func init() {
+	Cmd.AddCommand(clearCmd)
+}
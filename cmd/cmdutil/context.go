@@ -0,0 +1,197 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cmdutil
+
+import (
+	"context"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// Context returns context of command. It is cancelled on SIGINT or SIGTERM
+// and when command runs longer than time set with --timeout flag.
+// Second SIGINT terminates program immediately
+func Context() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if timeout := viper.GetDuration("JIRA_TIMEOUT"); timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+		cancelSignal := cancel
+		cancel = func() {
+			cancelTimeout()
+			cancelSignal()
+		}
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		defer signal.Stop(signals)
+		select {
+		case <-signals:
+			logrus.Warnln("Interrupted, cancelling pending requests. Press Ctrl-C again to exit immediately")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// isCancelled returns true when error was caused by cancelled or timed out context
+func isCancelled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// Issue results reported by bulk commands
+const (
+	ResultOk      = "ok"
+	ResultSkipped = "skipped"
+	ResultFailed  = "failed"
+	ResultNotDone = "not done"
+)
+
+// Exit statuses of bulk commands
+const (
+	ExitFailed  = 1
+	ExitStopped = 2
+)
+
+// Result type represents outcome of bulk command for single issue
+type Result struct {
+	Key    string `json:"key"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Progress type records result of every issue processed by bulk command,
+// so report can be printed when command finishes or is interrupted
+type Progress struct {
+	mu       sync.Mutex
+	keys     []string
+	results  map[string]Result
+	failFast bool
+	stopped  bool
+	cancel   context.CancelFunc
+}
+
+// NewProgress method creates progress of bulk command processing given issues.
+// Returned context is cancelled after first failure when failFast is true
+func NewProgress(ctx context.Context, keys []string, failFast bool) (context.Context, *Progress) {
+	ctx, cancel := context.WithCancel(ctx)
+	return ctx, &Progress{
+		keys:     keys,
+		results:  make(map[string]Result),
+		failFast: failFast,
+		cancel:   cancel,
+	}
+}
+
+// Done method records result of issue processing. Errors other than cancellation are printed
+func (p *Progress) Done(key string, err error) {
+	if isCancelled(err) {
+		return
+	}
+	if err != nil {
+		PrintError(err)
+		p.record(Result{Key: key, Status: ResultFailed, Reason: err.Error()})
+		return
+	}
+	p.record(Result{Key: key, Status: ResultOk})
+}
+
+// Succeed method records successfully processed issue with description of what was done
+func (p *Progress) Succeed(key string, reason string) {
+	p.record(Result{Key: key, Status: ResultOk, Reason: reason})
+}
+
+// Skip method records issue left unchanged on purpose
+func (p *Progress) Skip(key string, reason string) {
+	logrus.Infof("%s skipped: %s\n", key, reason)
+	p.record(Result{Key: key, Status: ResultSkipped, Reason: reason})
+}
+
+func (p *Progress) record(result Result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.results[result.Key] = result
+	if result.Status == ResultFailed && p.failFast && !p.stopped {
+		p.stopped = true
+		p.cancel()
+	}
+}
+
+// Failed method returns true when processing of any issue failed
+func (p *Progress) Failed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, r := range p.results {
+		if r.Status == ResultFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// Results method returns result of every issue in order of given keys.
+// Issues which were not processed are reported as not done
+func (p *Progress) Results() []Result {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	results := make([]Result, 0, len(p.keys))
+	for _, key := range p.keys {
+		result, ok := p.results[key]
+		if !ok {
+			result = Result{Key: key, Status: ResultNotDone}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Finish method prints report of every issue. It exits with ExitStopped when context was cancelled, timed out
+// or command was stopped by --fail-fast before processing all issues and with ExitFailed when any issue failed
+func (p *Progress) Finish(ctx context.Context) {
+	defer p.cancel()
+	results := p.Results()
+	table := Table{Header: []string{"KEY", "STATUS", "REASON"}}
+	counts := make(map[string]int)
+	for _, r := range results {
+		table.Append(r.Key, r.Status, r.Reason)
+		counts[r.Status]++
+	}
+	Print(results, table)
+	p.mu.Lock()
+	stopped := p.stopped
+	p.mu.Unlock()
+	if counts[ResultNotDone] > 0 {
+		if stopped {
+			logrus.Warnln("Command stopped after first failure")
+		} else if ctx.Err() != nil {
+			logrus.Warnf("Command stopped: %s\n", ctx.Err())
+		}
+	}
+	logrus.Infof("Ok: %d, skipped: %d, failed: %d, not done: %d\n", counts[ResultOk], counts[ResultSkipped], counts[ResultFailed], counts[ResultNotDone])
+	if counts[ResultNotDone] > 0 {
+		os.Exit(ExitStopped)
+	}
+	if counts[ResultFailed] > 0 {
+		os.Exit(ExitFailed)
+	}
+}
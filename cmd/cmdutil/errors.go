@@ -0,0 +1,55 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cmdutil
+
+import (
+	"errors"
+	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/jiraApi"
+	"os"
+)
+
+// PrintError logs error. JIRA API errors are logged with every server message and field error in separate line
+func PrintError(err error) {
+	if isCancelled(err) {
+		logrus.Errorf("Command stopped: %s\n", err)
+		return
+	}
+	var apiError *jiraApi.APIError
+	if !errors.As(err, &apiError) {
+		logrus.Errorln(err)
+		return
+	}
+	if err != error(apiError) {
+		logrus.Errorln(err)
+	}
+	logrus.Errorf("%s %s: http error: %d\n", apiError.Method, apiError.Endpoint, apiError.StatusCode)
+	for _, message := range apiError.ErrorMessages {
+		logrus.Errorf("  %s\n", message)
+	}
+	for _, field := range apiError.Fields() {
+		logrus.Errorf("  %s: %s\n", field, apiError.Errors[field])
+	}
+}
+
+// CheckErr prints error and exits with status 1 when error is not nil
+func CheckErr(err error) {
+	if err == nil {
+		return
+	}
+	PrintError(err)
+	os.Exit(1)
+}
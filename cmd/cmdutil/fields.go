@@ -0,0 +1,56 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cmdutil
+
+import (
+	"fmt"
+	"github.com/sotomskir/jira-cli/jiraApi"
+	"strings"
+)
+
+// ParseFields parses field values given as NAME=VALUE, e.g. "Story Points=5" or "customfield_10002=5"
+func ParseFields(pairs []string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, pair := range pairs {
+		name, value, err := parseField(pair)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// ParseFieldEdits parses field values given as NAME=VALUE into edits with given operation, keeping their order
+func ParseFieldEdits(operation string, pairs []string) ([]jiraApi.FieldEdit, error) {
+	edits := make([]jiraApi.FieldEdit, 0, len(pairs))
+	for _, pair := range pairs {
+		name, value, err := parseField(pair)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, jiraApi.FieldEdit{Field: name, Operation: operation, Value: value})
+	}
+	return edits, nil
+}
+
+func parseField(pair string) (string, string, error) {
+	parts := strings.SplitN(pair, "=", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return "", "", fmt.Errorf("invalid field: %s, expected NAME=VALUE", pair)
+	}
+	return strings.TrimSpace(parts[0]), parts[1], nil
+}
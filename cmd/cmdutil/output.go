@@ -0,0 +1,142 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cmdutil
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+	"io"
+	"os"
+	"strings"
+)
+
+// OutputFormats lists formats accepted by --output flag
+var OutputFormats = []string{"table", "json", "yaml", "csv", "tsv", "template", "template-file"}
+
+// Table type is tabular view of command result printed by table, csv and tsv formats
+type Table struct {
+	Header []string
+	Rows   [][]string
+	Footer []string
+}
+
+// Append method adds row to table
+func (t *Table) Append(row ...string) {
+	t.Rows = append(t.Rows, row)
+}
+
+// OutputFormat returns format selected with --output flag or JIRA_OUTPUT setting, table by default.
+// Template given with --template flag selects template format
+func OutputFormat() string {
+	if viper.GetString("JIRA_TEMPLATE") != "" {
+		return "template"
+	}
+	format := strings.ToLower(strings.SplitN(viper.GetString("JIRA_OUTPUT"), "=", 2)[0])
+	switch format {
+	case "":
+		return "table"
+	case "template-file":
+		return "template"
+	}
+	return format
+}
+
+// CheckOutputFormat returns error when selected output format is not supported
+func CheckOutputFormat() error {
+	format := OutputFormat()
+	if format == "template" {
+		_, err := OutputTemplate()
+		return err
+	}
+	for _, f := range OutputFormats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format: %s, expected one of: %s", format, strings.Join(OutputFormats, ", "))
+}
+
+// Print writes command result to standard output in selected format and exits with status 1 on failure.
+// Json, yaml and template formats print data, table, csv and tsv formats print table
+func Print(data interface{}, table Table) {
+	PrintItems(data, data, table)
+}
+
+// PrintItems works like Print, but template format is rendered for items instead of data,
+// e.g. for issues of search response
+func PrintItems(data interface{}, items interface{}, table Table) {
+	if OutputFormat() == "template" {
+		tmpl, err := OutputTemplate()
+		CheckErr(err)
+		CheckErr(ExecuteTemplate(os.Stdout, tmpl, items))
+		return
+	}
+	CheckErr(Fprint(os.Stdout, OutputFormat(), data, table))
+}
+
+// Fprint writes command result in given format
+func Fprint(w io.Writer, format string, data interface{}, table Table) error {
+	switch format {
+	case "table":
+		writer := tablewriter.NewWriter(w)
+		writer.SetHeader(table.Header)
+		writer.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+		writer.SetCenterSeparator("|")
+		writer.AppendBulk(table.Rows)
+		if table.Footer != nil {
+			writer.SetFooter(table.Footer)
+		}
+		writer.Render()
+		return nil
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	case "yaml":
+		// models have only json tags, so yaml is converted from json to keep the same field names
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		var value interface{}
+		if err := yaml.Unmarshal(encoded, &value); err != nil {
+			return err
+		}
+		encoded, err = yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(encoded)
+		return err
+	case "csv", "tsv":
+		writer := csv.NewWriter(w)
+		if format == "tsv" {
+			writer.Comma = '\t'
+		}
+		if err := writer.Write(table.Header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(table.Rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	}
+	return fmt.Errorf("unknown output format: %s, expected one of: %s", format, strings.Join(OutputFormats, ", "))
+}
@@ -0,0 +1,116 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cmdutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"github.com/spf13/viper"
+	"gotest.tools/assert"
+	"testing"
+)
+
+// setOutput sets output settings for single test and returns function clearing them
+func setOutput(settings map[string]interface{}) func() {
+	for key, value := range settings {
+		viper.Set(key, value)
+	}
+	return func() {
+		for key := range settings {
+			viper.Set(key, "")
+		}
+	}
+}
+
+func TestFprint(t *testing.T) {
+	table := Table{Header: []string{"KEY", "SUMMARY"}}
+	table.Append("TEST-1", "First issue")
+	table.Append("TEST-2", "Second, \"quoted\"")
+	data := []map[string]string{{"key": "TEST-1"}, {"key": "TEST-2"}}
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"table", "|  KEY   |     SUMMARY      |\n" +
+			"|--------|------------------|\n" +
+			"| TEST-1 | First issue      |\n" +
+			"| TEST-2 | Second, \"quoted\" |\n"},
+		{"json", "[\n  {\n    \"key\": \"TEST-1\"\n  },\n  {\n    \"key\": \"TEST-2\"\n  }\n]\n"},
+		{"yaml", "- key: TEST-1\n- key: TEST-2\n"},
+		{"csv", "KEY,SUMMARY\nTEST-1,First issue\nTEST-2,\"Second, \"\"quoted\"\"\"\n"},
+		{"tsv", "KEY\tSUMMARY\nTEST-1\tFirst issue\nTEST-2\t\"Second, \"\"quoted\"\"\"\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			out := bytes.Buffer{}
+			assert.NilError(t, Fprint(&out, test.format, data, table))
+			assert.Equal(t, out.String(), test.expected)
+		})
+	}
+}
+
+func TestFprintYamlFieldNames(t *testing.T) {
+	fields := models.Fields{Summary: "Summary"}
+	assert.NilError(t, fields.Set("customfield_10002", 5))
+	issue := models.Issue{Key: "TEST-1", Fields: fields}
+	out := bytes.Buffer{}
+	assert.NilError(t, Fprint(&out, "yaml", issue, Table{}))
+	assert.Equal(t, out.String(), "fields:\n  customfield_10002: 5\n  summary: Summary\nkey: TEST-1\n")
+
+	out.Reset()
+	assert.NilError(t, Fprint(&out, "json", issue, Table{}))
+	decoded := struct {
+		Fields map[string]interface{} `json:"fields"`
+	}{}
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, decoded.Fields["customfield_10002"], 5.0)
+}
+
+func TestFprintUnknownFormat(t *testing.T) {
+	err := Fprint(&bytes.Buffer{}, "xml", nil, Table{})
+	assert.Error(t, err, "unknown output format: xml, expected one of: table, json, yaml, csv, tsv, template, template-file")
+}
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		output   string
+		template string
+		expected string
+		err      string
+	}{
+		{output: "", expected: "table"},
+		{output: "JSON", expected: "json"},
+		{output: "tsv", expected: "tsv"},
+		{output: "template={{.Key}}", expected: "template"},
+		{output: "template-file=missing.tmpl", expected: "template", err: "open missing.tmpl"},
+		{output: "template", expected: "template", err: "template is missing"},
+		{output: "json", template: "{{.Key}}", expected: "template"},
+		{output: "xml", expected: "xml", err: "unknown output format: xml"},
+	}
+	for _, test := range tests {
+		t.Run(test.output, func(t *testing.T) {
+			defer setOutput(map[string]interface{}{"JIRA_OUTPUT": test.output, "JIRA_TEMPLATE": test.template})()
+			assert.Equal(t, OutputFormat(), test.expected)
+			err := CheckOutputFormat()
+			if test.err == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
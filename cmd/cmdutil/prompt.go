@@ -0,0 +1,120 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cmdutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// stdin is shared by prompts so input buffered by one prompt is not lost for the next one
+var stdin = bufio.NewReader(os.Stdin)
+
+// Prompt prints label to standard error and returns trimmed line read from standard input.
+// Empty answers are repeated when required is true
+func Prompt(label string, required bool) (string, error) {
+	for {
+		fmt.Fprintf(os.Stderr, "%s: ", label)
+		text, err := stdin.ReadString('\n')
+		text = strings.TrimSpace(text)
+		if err != nil && (err != io.EOF || text == "") {
+			fmt.Fprintln(os.Stderr)
+			return "", err
+		}
+		if text != "" || !required {
+			return text, nil
+		}
+	}
+}
+
+// Choose prints numbered options and returns option selected by number or case insensitive name.
+// Several options separated with comma can be selected when multiple is true
+func Choose(label string, options []string, multiple bool) (string, error) {
+	for i, option := range options {
+		fmt.Fprintf(os.Stderr, "%3d) %s\n", i+1, option)
+	}
+	for {
+		answer, err := Prompt(label, true)
+		if err != nil {
+			return "", err
+		}
+		items := []string{answer}
+		if multiple {
+			items = strings.Split(answer, ",")
+		}
+		selected := make([]string, 0, len(items))
+		for _, item := range items {
+			if option, ok := chooseOption(options, strings.TrimSpace(item)); ok {
+				selected = append(selected, option)
+			}
+		}
+		if len(selected) == len(items) {
+			return strings.Join(selected, ","), nil
+		}
+		fmt.Fprintf(os.Stderr, "choose number from 1 to %d or name of option\n", len(options))
+	}
+}
+
+// chooseOption returns option given by number or case insensitive name
+func chooseOption(options []string, answer string) (string, bool) {
+	if i, err := strconv.Atoi(answer); err == nil && i > 0 && i <= len(options) {
+		return options[i-1], true
+	}
+	for _, option := range options {
+		if strings.EqualFold(option, answer) {
+			return option, true
+		}
+	}
+	return "", false
+}
+
+// Edit opens text in editor given by VISUAL or EDITOR environment variable, vi by default,
+// and returns edited text without trailing new lines
+func Edit(text string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	file, err := ioutil.TempFile("", "jira-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	args := append(strings.Fields(editor), file.Name())
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stderr, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %s: %w", editor, err)
+	}
+	edited, err := ioutil.ReadFile(file.Name())
+	return strings.TrimRight(string(edited), "\n"), err
+}
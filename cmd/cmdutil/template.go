@@ -0,0 +1,145 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cmdutil
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateColors maps color names accepted by color template function to ANSI codes
+var templateColors = map[string]string{
+	"bold":    "1",
+	"black":   "30",
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+	"white":   "37",
+}
+
+// templateDateLayouts lists date formats returned by JIRA API
+var templateDateLayouts = []string{"2006-01-02T15:04:05.000-0700", time.RFC3339, "2006-01-02"}
+
+// TemplateFuncs are helper functions available in output templates
+var TemplateFuncs = template.FuncMap{
+	"join":    templateJoin,
+	"date":    templateDate,
+	"pad":     templatePad,
+	"padLeft": templatePadLeft,
+	"color":   templateColor,
+}
+
+// templateJoin joins slice items with separator
+func templateJoin(sep string, items interface{}) string {
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fmt.Sprint(items)
+	}
+	parts := make([]string, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		parts[i] = fmt.Sprint(value.Index(i).Interface())
+	}
+	return strings.Join(parts, sep)
+}
+
+// templateDate formats JIRA date with Go time layout. Value which is not a date is returned unchanged
+func templateDate(layout string, value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(layout)
+	case string:
+		for _, l := range templateDateLayouts {
+			if t, err := time.Parse(l, v); err == nil {
+				return t.Format(layout)
+			}
+		}
+		return v
+	}
+	return fmt.Sprint(value)
+}
+
+// templatePad appends spaces to value up to given width
+func templatePad(width int, value interface{}) string {
+	return fmt.Sprintf("%-*s", width, fmt.Sprint(value))
+}
+
+// templatePadLeft prepends spaces to value up to given width
+func templatePadLeft(width int, value interface{}) string {
+	return fmt.Sprintf("%*s", width, fmt.Sprint(value))
+}
+
+// templateColor wraps value in ANSI color code, unless colors are disabled with --no-color
+func templateColor(name string, value interface{}) (string, error) {
+	code, ok := templateColors[strings.ToLower(name)]
+	if !ok {
+		return "", fmt.Errorf("unknown color: %s", name)
+	}
+	if viper.GetBool("JIRA_NO_COLOR") {
+		return fmt.Sprint(value), nil
+	}
+	return fmt.Sprintf("\x1b[%sm%v\x1b[0m", code, value), nil
+}
+
+// OutputTemplate returns template given with --template flag, -o template=TEXT or -o template-file=PATH
+func OutputTemplate() (*template.Template, error) {
+	text := viper.GetString("JIRA_TEMPLATE")
+	if text == "" {
+		parts := strings.SplitN(viper.GetString("JIRA_OUTPUT"), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("template is missing, use -o template=TEXT, -o template-file=PATH or --template TEXT")
+		}
+		text = parts[1]
+		if strings.ToLower(parts[0]) == "template-file" {
+			content, err := ioutil.ReadFile(parts[1])
+			if err != nil {
+				return nil, err
+			}
+			text = strings.TrimSuffix(string(content), "\n")
+		}
+	}
+	return template.New("output").Funcs(TemplateFuncs).Parse(text)
+}
+
+// ExecuteTemplate renders template for every item of slice or once for other values. Every rendering ends with new line
+func ExecuteTemplate(w io.Writer, tmpl *template.Template, items interface{}) error {
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return executeTemplateLine(w, tmpl, items)
+	}
+	for i := 0; i < value.Len(); i++ {
+		if err := executeTemplateLine(w, tmpl, value.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func executeTemplateLine(w io.Writer, tmpl *template.Template, item interface{}) error {
+	if err := tmpl.Execute(w, item); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,105 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cmdutil
+
+import (
+	"bytes"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestOutputTemplate(t *testing.T) {
+	file, err := ioutil.TempFile("", "jira-cli-*.tmpl")
+	assert.NilError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("{{.Key}} from file\n")
+	assert.NilError(t, err)
+	assert.NilError(t, file.Close())
+
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		expected string
+	}{
+		{"output", map[string]interface{}{"JIRA_OUTPUT": "template={{.Key}} from output"}, "TEST-1 from output\n"},
+		{"output file", map[string]interface{}{"JIRA_OUTPUT": "template-file=" + file.Name()}, "TEST-1 from file\n"},
+		{"template flag", map[string]interface{}{"JIRA_OUTPUT": "json", "JIRA_TEMPLATE": "{{.Key}} from flag"}, "TEST-1 from flag\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer setOutput(test.settings)()
+			tmpl, err := OutputTemplate()
+			assert.NilError(t, err)
+			out := bytes.Buffer{}
+			assert.NilError(t, ExecuteTemplate(&out, tmpl, models.Issue{Key: "TEST-1"}))
+			assert.Equal(t, out.String(), test.expected)
+		})
+	}
+}
+
+func TestExecuteTemplateItems(t *testing.T) {
+	tmpl := template.Must(template.New("output").Funcs(TemplateFuncs).Parse("{{.Key}}"))
+	issues := []models.Issue{{Key: "TEST-1"}, {Key: "TEST-2"}}
+	out := bytes.Buffer{}
+	assert.NilError(t, ExecuteTemplate(&out, tmpl, issues))
+	assert.Equal(t, out.String(), "TEST-1\nTEST-2\n")
+
+	tmpl = template.Must(template.New("output").Funcs(TemplateFuncs).Parse("{{.Total}}: {{range .Issues}}{{.Key}} {{end}}"))
+	out.Reset()
+	assert.NilError(t, ExecuteTemplate(&out, tmpl, models.IssueList{Total: 2, Issues: issues}))
+	assert.Equal(t, out.String(), "2: TEST-1 TEST-2 \n")
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	defer setOutput(map[string]interface{}{"JIRA_NO_COLOR": false})()
+	tests := []struct {
+		text     string
+		data     interface{}
+		expected string
+	}{
+		{`{{join ", " .}}`, []string{"ci", "release"}, "ci, release"},
+		{`{{join ", " .}}`, "single", "single"},
+		{`{{date "2006-01-02 15:04" .}}`, "2019-03-01T10:20:30.000+0100", "2019-03-01 10:20"},
+		{`{{date "02.01.2006" .}}`, "2019-03-01", "01.03.2019"},
+		{`{{date "02.01.2006" .}}`, time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC), "01.03.2019"},
+		{`{{date "02.01.2006" .}}`, "not a date", "not a date"},
+		{`[{{pad 6 .}}]`, "Done", "[Done  ]"},
+		{`[{{padLeft 6 .}}]`, 42, "[    42]"},
+		{`{{color "red" .}}`, "Blocker", "\x1b[31mBlocker\x1b[0m"},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			tmpl := template.Must(template.New("output").Funcs(TemplateFuncs).Parse(test.text))
+			out := bytes.Buffer{}
+			assert.NilError(t, tmpl.Execute(&out, test.data))
+			assert.Equal(t, out.String(), test.expected)
+		})
+	}
+}
+
+func TestTemplateColor(t *testing.T) {
+	defer setOutput(map[string]interface{}{"JIRA_NO_COLOR": true})()
+	value, err := templateColor("green", "Done")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "Done")
+	_, err = templateColor("pink", "Done")
+	assert.Error(t, err, "unknown color: pink")
+}
@@ -0,0 +1,33 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// contextCmd represents the context command
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Manage server profiles",
+	Long: `Manage server profiles saved in config file.
+Every profile has server URL and authentication settings. Current profile is used
+by commands unless other profile is selected with --profile flag or JIRA_PROFILE env variable.
+Credentials of profile are saved with: jira-cli login --profile NAME`,
+}
+
+func init() {
+	rootCmd.AddCommand(contextCmd)
+}
@@ -0,0 +1,76 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/sotomskir/jira-cli/jiraApi"
+	"github.com/spf13/cobra"
+	"strings"
+)
+
+// contextAddCmd represents the context add command
+var contextAddCmd = &cobra.Command{
+	Use:   "add NAME",
+	Short: "Add server profile",
+	Long: `Add server profile. Secrets are not accepted by this command,
+save them with: jira-cli login --profile NAME`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		server, _ := cmd.Flags().GetString("server")
+		auth, _ := cmd.Flags().GetString("auth")
+		user, _ := cmd.Flags().GetString("user")
+		consumerKey, _ := cmd.Flags().GetString("consumer-key")
+		privateKey, _ := cmd.Flags().GetString("private-key")
+		if server == "" {
+			cmdutil.CheckErr(errors.New("server URL is required, set it with --server flag"))
+		}
+		auth = strings.ToLower(auth)
+		if secretKind(auth) == "" {
+			cmdutil.CheckErr(fmt.Errorf("unknown authentication type: %s, expected one of: %s", auth, strings.Join(jiraApi.AuthTypes, ", ")))
+		}
+		profiles := savedProfiles()
+		if findProfile(profiles, name) != nil {
+			cmdutil.CheckErr(fmt.Errorf("profile %s already exists", name))
+		}
+		profiles = append(profiles, profile{
+			Name:        name,
+			Url:         strings.TrimRight(server, "/"),
+			Auth:        auth,
+			User:        user,
+			ConsumerKey: consumerKey,
+			PrivateKey:  privateKey,
+		})
+		current := currentProfile()
+		if current == "" {
+			current = name
+		}
+		cmdutil.CheckErr(writeProfiles(profiles, current, nil))
+		logrus.Infof("Profile %s added, save its credentials with: jira-cli login --profile %s\n", name, name)
+	},
+}
+
+func init() {
+	contextCmd.AddCommand(contextAddCmd)
+	contextAddCmd.Flags().StringP("server", "s", "", "Jira server url")
+	contextAddCmd.Flags().String("auth", jiraApi.AuthBasic, "Authentication type: basic, bearer, cloud-token or oauth1")
+	contextAddCmd.Flags().StringP("user", "u", "", "Jira username, or account e-mail for cloud-token")
+	contextAddCmd.Flags().String("consumer-key", "", "OAuth consumer key of application link")
+	contextAddCmd.Flags().String("private-key", "", "PEM file with RSA private key of OAuth application link")
+}
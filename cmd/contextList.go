@@ -0,0 +1,90 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// contextView type represents profile printed by context commands, without secrets
+type contextView struct {
+	Current     bool   `json:"current"`
+	Name        string `json:"name"`
+	Server      string `json:"server"`
+	Auth        string `json:"auth"`
+	User        string `json:"user,omitempty"`
+	ConsumerKey string `json:"consumerKey,omitempty"`
+	PrivateKey  string `json:"privateKey,omitempty"`
+	Credentials string `json:"credentials"`
+}
+
+// contextListCmd represents the context list command
+var contextListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List server profiles",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		current := currentProfile()
+		table := cmdutil.Table{Header: []string{"CURRENT", "NAME", "SERVER", "AUTH", "USER", "CREDENTIALS"}}
+		views := make([]contextView, 0)
+		for _, p := range savedProfiles() {
+			view := newContextView(p, current)
+			views = append(views, view)
+			mark := ""
+			if view.Current {
+				mark = "*"
+			}
+			table.Append(mark, view.Name, view.Server, view.Auth, view.User, view.Credentials)
+		}
+		cmdutil.Print(views, table)
+	},
+}
+
+func init() {
+	contextCmd.AddCommand(contextListCmd)
+}
+
+// newContextView returns view of profile, current is name of profile used by commands
+func newContextView(p profile, current string) contextView {
+	return contextView{
+		Current:     p.Name != "" && p.Name == current,
+		Name:        p.Name,
+		Server:      p.Url,
+		Auth:        authType(p),
+		User:        p.User,
+		Credentials: credentialsLocation(p),
+	}
+}
+
+// authType returns authentication type of profile
+func authType(p profile) string {
+	if p.Auth == "" {
+		return "basic"
+	}
+	return p.Auth
+}
+
+// credentialsLocation describes where secret of profile is kept
+func credentialsLocation(p profile) string {
+	switch field := p.secret(); {
+	case p.Store != "":
+		return p.Store + " store"
+	case field != nil && *field != "":
+		return "config file"
+	}
+	return "none"
+}
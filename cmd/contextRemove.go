@@ -0,0 +1,69 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// contextRemoveCmd represents the context remove command
+var contextRemoveCmd = &cobra.Command{
+	Use:     "remove NAME",
+	Aliases: []string{"rm"},
+	Short:   "Remove server profile and its credentials",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profiles := savedProfiles()
+		removed := findProfile(profiles, args[0])
+		if removed == nil {
+			cmdutil.CheckErr(fmt.Errorf("unknown profile: %s, see jira-cli context list", args[0]))
+		}
+		p := *removed
+		kept := make([]profile, 0, len(profiles))
+		shared := false
+		for _, other := range profiles {
+			if other.Name == p.Name {
+				continue
+			}
+			// secret is kept while other profile uses it
+			if other.Store == p.Store && other.secretKey() == p.secretKey() {
+				shared = true
+			}
+			kept = append(kept, other)
+		}
+		current := currentProfile()
+		if current == p.Name {
+			current = ""
+		}
+		cmdutil.CheckErr(writeProfiles(kept, current, nil))
+		if p.Store != "" && !shared {
+			store, err := credentialStore(p.Store)
+			if err == nil {
+				err = store.Delete(p.secretKey())
+			}
+			if err != nil {
+				logrus.Warnf("Cannot remove credentials of profile %s: %s\n", p.Name, err)
+			}
+		}
+		logrus.Infof("Profile %s removed\n", p.Name)
+	},
+}
+
+func init() {
+	contextCmd.AddCommand(contextRemoveCmd)
+}
@@ -0,0 +1,54 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"fmt"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// contextShowCmd represents the context show command
+var contextShowCmd = &cobra.Command{
+	Use:   "show [NAME]",
+	Short: "Show server profile, default is profile used by commands",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := activeProfile()
+		if len(args) > 0 {
+			found := findProfile(savedProfiles(), args[0])
+			if found == nil {
+				err = fmt.Errorf("unknown profile: %s, see jira-cli context list", args[0])
+			} else {
+				p, err = *found, nil
+			}
+		}
+		cmdutil.CheckErr(err)
+		view := newContextView(p, currentProfile())
+		view.ConsumerKey = p.ConsumerKey
+		view.PrivateKey = p.PrivateKey
+		current := "no"
+		if view.Current {
+			current = "yes"
+		}
+		table := cmdutil.Table{Header: []string{"NAME", "SERVER", "AUTH", "USER", "CONSUMER KEY", "PRIVATE KEY", "CREDENTIALS", "CURRENT"}}
+		table.Append(view.Name, view.Server, view.Auth, view.User, view.ConsumerKey, view.PrivateKey, view.Credentials, current)
+		cmdutil.Print(view, table)
+	},
+}
+
+func init() {
+	contextCmd.AddCommand(contextShowCmd)
+}
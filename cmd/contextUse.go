@@ -0,0 +1,41 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// contextUseCmd represents the context use command
+var contextUseCmd = &cobra.Command{
+	Use:   "use NAME",
+	Short: "Set current server profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profiles := savedProfiles()
+		if findProfile(profiles, args[0]) == nil {
+			cmdutil.CheckErr(fmt.Errorf("unknown profile: %s, see jira-cli context list", args[0]))
+		}
+		cmdutil.CheckErr(writeProfiles(profiles, args[0], nil))
+		logrus.Infof("Current profile: %s\n", args[0])
+	},
+}
+
+func init() {
+	contextCmd.AddCommand(contextUseCmd)
+}
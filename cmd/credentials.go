@@ -0,0 +1,98 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mitchellh/go-homedir"
+	"github.com/sotomskir/jira-cli/credentials"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/ssh/terminal"
+	"os"
+	"path"
+	"syscall"
+)
+
+// stores keeps credential stores used by command, so passphrase of credentials file is asked once
+var stores = make(map[string]credentials.Store)
+
+// credentialStore returns credential store of given name. Auto selects credential helper when JIRA_CREDENTIAL_HELPER is set,
+// Secret Service keyring when it is available and passphrase encrypted file otherwise
+func credentialStore(name string) (credentials.Store, error) {
+	if name == "" || name == "auto" {
+		switch {
+		case viper.GetString("JIRA_CREDENTIAL_HELPER") != "":
+			name = credentials.StoreHelper
+		case credentials.NewKeyring().Available():
+			name = credentials.StoreKeyring
+		default:
+			name = credentials.StoreFile
+		}
+	}
+	if store, ok := stores[name]; ok {
+		return store, nil
+	}
+	var store credentials.Store
+	switch name {
+	case credentials.StoreKeyring:
+		store = credentials.NewKeyring()
+	case credentials.StoreFile:
+		file, err := credentialsFile()
+		if err != nil {
+			return nil, err
+		}
+		store = credentials.NewEncryptedFile(file, credentialsPassphrase)
+	case credentials.StoreHelper:
+		helper := viper.GetString("JIRA_CREDENTIAL_HELPER")
+		if helper == "" {
+			return nil, errors.New("credential helper is not set, set it with JIRA_CREDENTIAL_HELPER")
+		}
+		store = credentials.NewHelper(helper)
+	default:
+		return nil, fmt.Errorf("unknown credential store: %s, expected one of: auto, keyring, file, helper", name)
+	}
+	stores[name] = store
+	return store, nil
+}
+
+// credentialsFile returns path of encrypted credentials file
+func credentialsFile() (string, error) {
+	if file := viper.GetString("JIRA_CREDENTIALS_FILE"); file != "" {
+		return file, nil
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(home, ".jira-cli.credentials"), nil
+}
+
+// credentialsPassphrase returns passphrase of credentials file read from JIRA_CREDENTIALS_PASSPHRASE or terminal.
+// Passphrase of new file is asked twice
+func credentialsPassphrase(create bool) (string, error) {
+	if passphrase := os.Getenv("JIRA_CREDENTIALS_PASSPHRASE"); passphrase != "" {
+		return passphrase, nil
+	}
+	if !terminal.IsTerminal(int(syscall.Stdin)) {
+		return "", errors.New("passphrase of credentials file is required, set it with JIRA_CREDENTIALS_PASSPHRASE")
+	}
+	passphrase := getPasswd("Credentials file passphrase: ")
+	if create && getPasswd("Repeat passphrase: ") != passphrase {
+		return "", errors.New("passphrases do not match")
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,74 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package issue
+
+import (
+	"context"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/sotomskir/jira-cli/jiraApi"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+)
+
+// promptIssueType asks for project key and issue type which were not given with flags
+func promptIssueType(ctx context.Context) {
+	var err error
+	if projectKey == "" {
+		projectKey, err = cmdutil.Prompt("Project key", true)
+		cmdutil.CheckErr(err)
+	}
+	if issueType != "" {
+		return
+	}
+	issueTypes, err := jiraApi.GetCreateIssueTypes(ctx, projectKey)
+	cmdutil.CheckErr(err)
+	names := make([]string, 0, len(issueTypes))
+	for _, t := range issueTypes {
+		names = append(names, t.Name)
+	}
+	issueType, err = cmdutil.Choose("Issue type", names, false)
+	cmdutil.CheckErr(err)
+}
+
+// promptSummary asks for summary and opens editor for description when they were not given with flags
+func promptSummary(meta models.IssueTypeMeta, descriptionSet bool) {
+	var err error
+	if summary == "" {
+		summary, err = cmdutil.Prompt("Summary", true)
+		cmdutil.CheckErr(err)
+	}
+	if _, ok := meta.Fields["description"]; ok && !descriptionSet {
+		description, err = cmdutil.Edit(description)
+		cmdutil.CheckErr(err)
+	}
+}
+
+// promptRequiredFields asks for values of required fields which are not set, allowed values are chosen from list
+func promptRequiredFields(ctx context.Context, meta models.IssueTypeMeta, fields *models.Fields, values map[string]string) {
+	for _, id := range meta.MissingFields(*fields) {
+		fieldMeta := meta.Fields[id]
+		var value string
+		var err error
+		if allowed := fieldMeta.AllowedNames(); len(allowed) > 0 {
+			multiple := fieldMeta.Schema != nil && fieldMeta.Schema.Type == "array"
+			value, err = cmdutil.Choose(fieldMeta.Name, allowed, multiple)
+		} else {
+			value, err = cmdutil.Prompt(fieldMeta.Name, true)
+		}
+		cmdutil.CheckErr(err)
+		values[id] = value
+		cmdutil.CheckErr(jiraApi.SetFields(ctx, fields, map[string]string{id: value}))
+	}
+}
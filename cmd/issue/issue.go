@@ -32,6 +32,9 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.AddCommand(inspectCmd)
 	Cmd.AddCommand(createCmd)
+	Cmd.AddCommand(searchCmd)
+	Cmd.AddCommand(editCmd)
+	Cmd.AddCommand(importCmd)
 	Cmd.AddCommand(worklog.Cmd)
 	Cmd.AddCommand(version.VersionCmd)
 	Cmd.AddCommand(transition.TransitionCmd)
@@ -16,39 +16,101 @@
 package issue
 
 import (
+	"fmt"
 	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
 	"github.com/spf13/cobra"
-	"os"
 )
 
 var summary string
 var description string
 var issueType string
 var projectKey string
+var createFields []string
+var createFile string
+var createVars []string
+var createDryRun bool
+var createInteractive bool
 
 // Cmd represents the issue command
 var createCmd = &cobra.Command{
 	Use:     "create",
 	Aliases: []string{"c"},
 	Short:   "Create new issue",
+	Long: `Create new issue with given summary, description, type and project,
+or create issues with sub-tasks and links from YAML or JSON file given with --file.
+
+Fields are checked against create screen of issue type before issue is created.
+With --interactive missing project, type and summary are prompted for, description
+is written in $EDITOR and required fields are prompted for with their allowed values.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		issue, err := jiraApi.CreateIssue(projectKey, summary, description, issueType, nil)
-		if err != nil {
-			logrus.Errorln(err)
-			os.Exit(1)
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		if createFile != "" {
+			vars, err := cmdutil.ParseFields(createVars)
+			cmdutil.CheckErr(err)
+			templates, err := readTemplates(createFile, vars)
+			cmdutil.CheckErr(err)
+			setTemplateDefaults(templates, projectKey, issueType)
+			if createDryRun {
+				previewTemplates(ctx, templates)
+				return
+			}
+			createFromTemplates(ctx, templates)
+			return
+		}
+		if createInteractive {
+			promptIssueType(ctx)
+		} else {
+			for _, flag := range []string{"summary", "description", "type", "project"} {
+				if !cmd.Flags().Changed(flag) {
+					cmdutil.CheckErr(fmt.Errorf("required flag \"%s\" not set, use it, --interactive or --file", flag))
+				}
+			}
+		}
+		meta, err := jiraApi.GetCreateMeta(ctx, projectKey, issueType)
+		cmdutil.CheckErr(err)
+		if createInteractive {
+			promptSummary(meta, cmd.Flags().Changed("description"))
+		}
+		fields := models.Fields{
+			Summary:     summary,
+			Project:     &models.Project{Key: projectKey},
+			Description: models.RichText(description),
+			IssueType:   &models.IssueType{Name: meta.Name},
+		}
+		values, err := cmdutil.ParseFields(createFields)
+		cmdutil.CheckErr(err)
+		cmdutil.CheckErr(jiraApi.SetFields(ctx, &fields, values))
+		if createInteractive {
+			promptRequiredFields(ctx, meta, &fields, values)
+		}
+		cmdutil.CheckErr(jiraApi.CheckCreateFields(ctx, meta, fields, values))
+		if createDryRun {
+			table := cmdutil.Table{Header: []string{"PROJECT", "TYPE", "SUMMARY"}}
+			table.Append(projectKey, meta.Name, summary)
+			cmdutil.Print(previewIssue{Fields: fields}, table)
+			return
 		}
+		issue, err := jiraApi.CreateIssueWithFields(ctx, fields)
+		cmdutil.CheckErr(err)
 		logrus.Infof("Created key: %s %s\n", issue.Key, issue.Self)
+		table := cmdutil.Table{Header: []string{"ID", "KEY", "SELF"}}
+		table.Append(issue.Id, issue.Key, issue.Self)
+		cmdutil.Print(issue, table)
 	},
 }
 
 func init() {
 	createCmd.Flags().StringVarP(&summary, "summary", "s", "", "Issue summary")
 	createCmd.Flags().StringVarP(&description, "description", "d", "", "Issue description")
-	createCmd.Flags().StringVarP(&issueType, "type", "t", "", "Issue type")
-	createCmd.Flags().StringVarP(&projectKey, "project", "p", "", "Project key")
-	createCmd.MarkFlagRequired("summary")
-	createCmd.MarkFlagRequired("description")
-	createCmd.MarkFlagRequired("type")
-	createCmd.MarkFlagRequired("project")
+	createCmd.Flags().StringVarP(&issueType, "type", "t", "", "Issue type, default type of issues created from file")
+	createCmd.Flags().StringVarP(&projectKey, "project", "p", "", "Project key, default project of issues created from file")
+	createCmd.Flags().StringArrayVar(&createFields, "field", nil, "Field value as NAME=VALUE, field is given by name or id, e.g. \"Story Points=5\". Array items are separated with comma. Can be repeated")
+	createCmd.Flags().StringVarP(&createFile, "file", "f", "", "YAML or JSON file with issue or list of issues to create, - reads standard input")
+	createCmd.Flags().StringArrayVar(&createVars, "var", nil, "Variable of file template as NAME=VALUE, used in file as {{.NAME}}. Can be repeated")
+	createCmd.Flags().BoolVarP(&createInteractive, "interactive", "i", false, "Prompt for missing project, type, summary and required fields, write description in $EDITOR")
+	createCmd.Flags().BoolVar(&createDryRun, "dry-run", false, "Print fields of issues without creating them")
 }
@@ -0,0 +1,97 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package issue
+
+import (
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/sotomskir/jira-cli/jiraApi"
+	"github.com/spf13/cobra"
+)
+
+var editSet []string
+var editAdd []string
+var editRemove []string
+
+// editShortcuts maps flags of issue edit command to fields they set
+var editShortcuts = []struct {
+	flag  string
+	field string
+}{
+	{"summary", "summary"},
+	{"description", "description"},
+	{"priority", "priority"},
+	{"assignee", "assignee"},
+	{"due-date", "duedate"},
+}
+
+// editCmd represents the issue edit command
+var editCmd = &cobra.Command{
+	Use:     "edit ISSUE_KEY [ISSUE_KEY...]",
+	Aliases: []string{"e"},
+	Args:    cobra.MinimumNArgs(1),
+	Short:   "Edit fields of issues",
+	Long: `Edit fields of issues with set, add and remove operations.
+Fields, operations and values are checked against edit screen of every issue before update is sent.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		issueKeys := args
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		edits := make([]jiraApi.FieldEdit, 0)
+		for _, s := range editShortcuts {
+			if !cmd.Flags().Changed(s.flag) {
+				continue
+			}
+			value, _ := cmd.Flags().GetString(s.flag)
+			if s.field == "assignee" && value == "me" {
+				user, err := jiraApi.CurrentUserId(ctx)
+				cmdutil.CheckErr(err)
+				value = user
+			}
+			edits = append(edits, jiraApi.FieldEdit{Field: s.field, Operation: jiraApi.OperationSet, Value: value})
+		}
+		operations := []struct {
+			name  string
+			pairs []string
+		}{{jiraApi.OperationSet, editSet}, {jiraApi.OperationAdd, editAdd}, {jiraApi.OperationRemove, editRemove}}
+		for _, o := range operations {
+			operationEdits, err := cmdutil.ParseFieldEdits(o.name, o.pairs)
+			cmdutil.CheckErr(err)
+			edits = append(edits, operationEdits...)
+		}
+		if len(edits) == 0 {
+			cmdutil.CheckErr(cmd.Usage())
+			return
+		}
+		ctx, progress := cmdutil.NewProgress(ctx, issueKeys, failFast)
+		jiraApi.ForEach(ctx, issueKeys, func(issueKey string) {
+			progress.Done(issueKey, jiraApi.EditIssue(ctx, issueKey, edits))
+		})
+		progress.Finish(ctx)
+	},
+}
+
+func init() {
+	editCmd.Flags().StringP("summary", "s", "", "Set issue summary")
+	editCmd.Flags().StringP("description", "d", "", "Set issue description")
+	editCmd.Flags().String("priority", "", "Set issue priority")
+	editCmd.Flags().String("assignee", "", "Set assignee, user name on Jira Server, account id on Jira Cloud or me. Empty value unassigns issue")
+	editCmd.Flags().String("due-date", "", "Set due date as YYYY-MM-DD, empty value clears it")
+	editCmd.Flags().StringArrayVar(&editSet, "set", nil, "Set field value as NAME=VALUE, field is given by name or id, e.g. \"Story Points=5\" or labels=ci,release. Can be repeated")
+	editCmd.Flags().StringArrayVar(&editAdd, "add", nil, "Add comma separated items to array field as NAME=VALUE, e.g. labels=ci or \"Fix Version/s=1.0.0\". Can be repeated")
+	editCmd.Flags().StringArrayVar(&editRemove, "remove", nil, "Remove comma separated items from array field as NAME=VALUE, e.g. components=UI. Can be repeated")
+	editCmd.Flags().Bool("fail-fast", false, "Stop editing remaining issues after first failure")
+}
@@ -0,0 +1,120 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package issue
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/sotomskir/jira-cli/jiraApi"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+var importFile string
+var importMap []string
+var importValues []string
+var importDryRun bool
+
+// importCmd represents the issue import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create issues from CSV file",
+	Long: `Create issue from every row of CSV file. First row names columns.
+Columns are mapped to fields of the same name, unless mapping is given with --map.
+Values can be built from columns with Go templates given with --value.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		mapping, err := cmdutil.ParseFields(importMap)
+		cmdutil.CheckErr(err)
+		values, err := cmdutil.ParseFields(importValues)
+		cmdutil.CheckErr(err)
+		templates, err := readCsvTemplates(importFile, mapping, values)
+		cmdutil.CheckErr(err)
+		setTemplateDefaults(templates, projectKey, issueType)
+		if importDryRun {
+			previewTemplates(ctx, templates)
+			return
+		}
+		createFromTemplates(ctx, templates)
+	},
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFile, "csv", "", "CSV file with issues, - reads standard input")
+	importCmd.Flags().StringArrayVar(&importMap, "map", nil, "Field filled from column as FIELD=COLUMN, e.g. \"Story Points=Points\". Can be repeated")
+	importCmd.Flags().StringArrayVar(&importValues, "value", nil, "Field value as FIELD=TEMPLATE rendered with columns of row, e.g. \"summary=Release {{.Version}}\". Can be repeated")
+	importCmd.Flags().StringVarP(&projectKey, "project", "p", "", "Project key of rows which don't set it")
+	importCmd.Flags().StringVarP(&issueType, "type", "t", "", "Issue type of rows which don't set it")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Print fields of issues without creating them")
+	importCmd.MarkFlagRequired("csv")
+}
+
+// readCsvTemplates returns issue template of every row of CSV file. Mapping gives column of field,
+// when it is empty every column fills field of the same name. Values are templates rendered with columns of row
+func readCsvTemplates(file string, mapping map[string]string, values map[string]string) ([]jiraApi.IssueTemplate, error) {
+	input := os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		input = f
+	}
+	rows, err := csv.NewReader(input).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("CSV file %s is empty", file)
+	}
+	header := rows[0]
+	if len(mapping) == 0 {
+		mapping = make(map[string]string, len(header))
+		for _, column := range header {
+			mapping[strings.TrimSpace(column)] = strings.TrimSpace(column)
+		}
+	}
+	templates := make([]jiraApi.IssueTemplate, 0, len(rows)-1)
+	for n, row := range rows[1:] {
+		columns := make(map[string]string, len(header))
+		for i, column := range header {
+			columns[strings.TrimSpace(column)] = row[i]
+		}
+		t := jiraApi.IssueTemplate{}
+		for field, column := range mapping {
+			value, ok := columns[column]
+			if !ok {
+				return nil, fmt.Errorf("unknown column: %s", column)
+			}
+			if value != "" {
+				t.Set(field, value)
+			}
+		}
+		for field, text := range values {
+			value, err := renderTemplate(field, text, columns)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", n+2, err)
+			}
+			t.Set(field, value)
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
@@ -16,13 +16,19 @@
 package issue
 
 import (
-	"github.com/olekukonko/tablewriter"
+	"context"
 	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
 	"github.com/spf13/cobra"
 	"os"
+	"strings"
 )
 
+var inspectFields []string
+var inspectExpand []string
+
 // Cmd represents the issue command
 var inspectCmd = &cobra.Command{
 	Use:     "inspect ISSUE_KEY [ISSUE_KEY...]",
@@ -31,23 +37,194 @@ var inspectCmd = &cobra.Command{
 	Short:   "Fetch data for given issue.",
 	Run: func(cmd *cobra.Command, args []string) {
 		keys := args[0:]
-		issues := jiraApi.GetIssues(keys)
-
-		if len(issues) > 0 {
-			table := tablewriter.NewWriter(os.Stdout)
-			table.SetHeader([]string{"ID", "KEY", "STATUS", "SUMMARY"})
-			table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
-			table.SetCenterSeparator("|")
-			for _, i := range issues {
-				table.Append([]string{i.Id, i.Key, i.Fields.Status.Name, i.Fields.Summary})
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		names := columnNames(inspectFields, inspectExpand, "status", "summary")
+		columns, err := fieldColumns(ctx, names)
+		cmdutil.CheckErr(err)
+		options := jiraApi.IssueOptions{Fields: requestedFields(inspectFields, columns), Expand: inspectExpand}
+		issues, failures := jiraApi.GetIssuesWithOptions(ctx, keys, options)
+		for _, key := range keys {
+			if err, ok := failures[key]; ok {
+				logrus.Errorf("%s: %s", key, err)
 			}
-			table.Render()
-		} else {
-			logrus.Errorf("None of the provided issues %v servers are resolvable.", keys)
-			os.Exit(1)
+		}
+		results, table := inspectResults(keys, issues, failures, names, columns)
+		cmdutil.Print(results, table)
+		if len(failures) > 0 {
+			os.Exit(cmdutil.ExitFailed)
 		}
 	},
 }
 
 func init() {
+	inspectCmd.Flags().StringSliceVar(&inspectFields, "fields", nil, "Comma separated fields to fetch and print, given by id or name, e.g. summary,status,\"Story Points\". All fields are fetched by default")
+	inspectCmd.Flags().StringSliceVar(&inspectExpand, "expand", nil, "Comma separated expansions to fetch: "+strings.Join(jiraApi.IssueExpands, ", "))
+}
+
+// columnNames returns fields printed in table, requested fields or default columns.
+// Requested transitions expansion is printed as additional column
+func columnNames(fields []string, expand []string, defaults ...string) []string {
+	names := make([]string, 0)
+	for _, f := range fields {
+		if !strings.HasPrefix(f, "*") && !strings.HasPrefix(f, "-") {
+			names = append(names, f)
+		}
+	}
+	if len(names) == 0 {
+		names = defaults
+	}
+	for _, e := range expand {
+		if e == "transitions" {
+			names = append(names, e)
+		}
+	}
+	return names
+}
+
+// fieldColumns returns field ids of printed columns by column name. Custom fields can be given by name
+func fieldColumns(ctx context.Context, names []string) (map[string]string, error) {
+	columns := make(map[string]string)
+	for _, name := range names {
+		if id, ok := issueColumnId(name); ok {
+			columns[name] = id
+			continue
+		}
+		field, err := jiraApi.GetField(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		columns[name] = field.Id
+	}
+	return columns, nil
+}
+
+// requestedFields returns fields to fetch with field names replaced by ids of their columns.
+// Field ids are case sensitive, so system fields are also sent with ids, e.g. status for Status
+func requestedFields(fields []string, columns map[string]string) []string {
+	requested := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if id, ok := columns[f]; ok {
+			f = id
+		}
+		requested = append(requested, f)
+	}
+	return requested
+}
+
+// inspectResult type represents fetched issue or error of issue key which could not be fetched
+type inspectResult struct {
+	models.Issue
+	Error string `json:"error,omitempty"`
+}
+
+// inspectResults returns result of every key in order of keys. Issues are given in order of keys without failed ones.
+// Table has error column only when any key failed
+func inspectResults(keys []string, issues []models.Issue, failures map[string]error, names []string, columns map[string]string) ([]inspectResult, cmdutil.Table) {
+	table := issueTable(nil, names, columns)
+	if len(failures) > 0 {
+		table.Header = append(table.Header, "ERROR")
+	}
+	results := make([]inspectResult, 0, len(keys))
+	for _, key := range keys {
+		if err, ok := failures[key]; ok {
+			results = append(results, inspectResult{Issue: models.Issue{Key: key}, Error: err.Error()})
+			row := make([]string, len(table.Header))
+			row[1], row[len(row)-1] = key, err.Error()
+			table.Append(row...)
+			continue
+		}
+		if len(issues) == 0 {
+			break
+		}
+		results = append(results, inspectResult{Issue: issues[0]})
+		row := issueRow(issues[0], names, columns)
+		if len(failures) > 0 {
+			row = append(row, "")
+		}
+		table.Append(row...)
+		issues = issues[1:]
+	}
+	return results, table
+}
+
+// issueTable returns table with id, key and given columns of issues
+func issueTable(issues []models.Issue, names []string, columns map[string]string) cmdutil.Table {
+	table := cmdutil.Table{Header: []string{"ID", "KEY"}}
+	for _, c := range names {
+		table.Header = append(table.Header, strings.ToUpper(c))
+	}
+	for _, i := range issues {
+		table.Append(issueRow(i, names, columns)...)
+	}
+	return table
+}
+
+// issueRow returns id, key and given columns of issue
+func issueRow(issue models.Issue, names []string, columns map[string]string) []string {
+	row := []string{issue.Id, issue.Key}
+	for _, c := range names {
+		row = append(row, issueColumn(issue, columns[c]))
+	}
+	return row
+}
+
+// issueColumns maps names of columns printed from issue model to their text
+var issueColumns = map[string]func(issue models.Issue) string{
+	"summary": func(issue models.Issue) string {
+		return issue.Fields.Summary
+	},
+	"description": func(issue models.Issue) string {
+		return string(issue.Fields.Description)
+	},
+	"status": func(issue models.Issue) string {
+		if issue.Fields.Status == nil {
+			return ""
+		}
+		return issue.Fields.Status.Name
+	},
+	"project": func(issue models.Issue) string {
+		if issue.Fields.Project == nil {
+			return ""
+		}
+		return issue.Fields.Project.Key
+	},
+	"issuetype": func(issue models.Issue) string {
+		if issue.Fields.IssueType == nil {
+			return ""
+		}
+		return issue.Fields.IssueType.Name
+	},
+	"fixVersions": func(issue models.Issue) string {
+		names := make([]string, 0)
+		for _, v := range issue.Fields.FixVersions {
+			names = append(names, v.Name)
+		}
+		return strings.Join(names, ", ")
+	},
+	"transitions": func(issue models.Issue) string {
+		names := make([]string, 0)
+		for _, t := range issue.Transitions {
+			names = append(names, t.Name)
+		}
+		return strings.Join(names, ", ")
+	},
+}
+
+// issueColumnId returns id of field printed from issue model. Names are matched case insensitive
+func issueColumnId(name string) (string, bool) {
+	for id := range issueColumns {
+		if strings.EqualFold(id, name) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// issueColumn returns text of issue field printed in table column, other fields are read from extra fields
+func issueColumn(issue models.Issue, id string) string {
+	if text, ok := issueColumns[id]; ok {
+		return text(issue)
+	}
+	return issue.Fields.Text(id)
 }
@@ -0,0 +1,61 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package issue
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gotest.tools/assert"
+	"testing"
+)
+
+func TestInspectResultsPartialFailure(t *testing.T) {
+	keys := []string{"TEST-1", "TEST-404", "TEST-2"}
+	issues := []models.Issue{
+		{Id: "1", Key: "TEST-1", Fields: models.Fields{Summary: "First"}},
+		{Id: "2", Key: "TEST-2", Fields: models.Fields{Summary: "Second"}},
+	}
+	failures := map[string]error{"TEST-404": errors.New("http error: 404: Issue does not exist")}
+	columns := map[string]string{"summary": "summary"}
+
+	results, table := inspectResults(keys, issues, failures, []string{"summary"}, columns)
+	assert.DeepEqual(t, table.Header, []string{"ID", "KEY", "SUMMARY", "ERROR"})
+	assert.DeepEqual(t, table.Rows, [][]string{
+		{"1", "TEST-1", "First", ""},
+		{"", "TEST-404", "", "http error: 404: Issue does not exist"},
+		{"2", "TEST-2", "Second", ""},
+	})
+	assert.Equal(t, len(results), 3)
+	assert.Equal(t, results[1].Key, "TEST-404")
+	assert.Equal(t, results[2].Fields.Summary, "Second")
+
+	data, err := json.Marshal(results)
+	assert.NilError(t, err)
+	decoded := make([]map[string]interface{}, 0)
+	assert.NilError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, decoded[0]["key"], "TEST-1")
+	assert.Equal(t, decoded[0]["error"], nil)
+	assert.Equal(t, decoded[1]["error"], "http error: 404: Issue does not exist")
+}
+
+func TestInspectResults(t *testing.T) {
+	issues := []models.Issue{{Id: "1", Key: "TEST-1", Fields: models.Fields{Summary: "First"}}}
+	results, table := inspectResults([]string{"TEST-1"}, issues, map[string]error{}, []string{"summary"}, map[string]string{"summary": "summary"})
+	assert.DeepEqual(t, table.Header, []string{"ID", "KEY", "SUMMARY"})
+	assert.DeepEqual(t, table.Rows, [][]string{{"1", "TEST-1", "First"}})
+	assert.Equal(t, results[0].Error, "")
+}
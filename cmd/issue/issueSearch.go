@@ -0,0 +1,72 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package issue
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/sotomskir/jira-cli/jiraApi"
+	"github.com/spf13/cobra"
+)
+
+var searchFields []string
+var searchQuery jiraApi.SearchQuery
+
+// searchCmd represents the issue search command
+var searchCmd = &cobra.Command{
+	Use:     "search [JQL]",
+	Aliases: []string{"s"},
+	Args:    cobra.MaximumNArgs(1),
+	Short:   "Search issues with JQL, saved filter or shortcut flags",
+	Run: func(cmd *cobra.Command, args []string) {
+		query := searchQuery
+		if len(args) > 0 {
+			query.JQL = args[0]
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		names := columnNames(searchFields, nil, "issuetype", "status", "summary")
+		columns, err := fieldColumns(ctx, names)
+		cmdutil.CheckErr(err)
+		fields := searchFields
+		if len(fields) == 0 {
+			fields = names
+		}
+		jql, err := jiraApi.SearchJQL(ctx, query)
+		cmdutil.CheckErr(err)
+		logrus.Debugf("JQL: %s\n", jql)
+		response, err := jiraApi.SearchIssues(ctx, jql, requestedFields(fields, columns), limit)
+		cmdutil.CheckErr(err)
+		if len(response.Issues) < response.Total {
+			logrus.Infof("Printed %d of %d issues\n", len(response.Issues), response.Total)
+		}
+		cmdutil.PrintItems(response, response.Issues, issueTable(response.Issues, names, columns))
+	},
+}
+
+func init() {
+	searchCmd.Flags().StringVar(&searchQuery.Filter, "filter", "", "Saved filter id or name joined with JQL")
+	searchCmd.Flags().StringSliceVar(&searchQuery.OrderBy, "order-by", nil, "Comma separated sort fields, prefix with minus or append desc for descending order, e.g. -priority,created")
+	searchCmd.Flags().StringSliceVar(&searchFields, "fields", nil, "Comma separated fields to fetch and print, given by id or name. Issue type, status and summary by default")
+	searchCmd.Flags().IntP("limit", "l", 0, "Maximal number of listed issues, 0 means no limit")
+	searchCmd.Flags().StringSliceVarP(&searchQuery.Project, "project", "p", nil, "Comma separated project keys")
+	searchCmd.Flags().StringSliceVar(&searchQuery.Status, "status", nil, "Comma separated statuses")
+	searchCmd.Flags().StringSliceVar(&searchQuery.Assignee, "assignee", nil, "Comma separated assignees, me for current user")
+	searchCmd.Flags().StringSliceVarP(&searchQuery.Type, "type", "t", nil, "Comma separated issue types")
+	searchCmd.Flags().StringSliceVar(&searchQuery.Version, "version", nil, "Comma separated fix versions")
+	searchCmd.Flags().StringSliceVar(&searchQuery.Label, "label", nil, "Comma separated labels")
+}
@@ -0,0 +1,153 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package issue
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/sotomskir/jira-cli/jiraApi"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"text/template"
+)
+
+// createdIssue type represents issue created from template printed in report
+type createdIssue struct {
+	Summary string `json:"summary"`
+	Parent  string `json:"parent,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Self    string `json:"self,omitempty"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// previewIssue type represents fields of issue printed by dry run
+type previewIssue struct {
+	Parent string        `json:"parent,omitempty"`
+	Fields models.Fields `json:"fields"`
+}
+
+// renderTemplate renders text with Go template using given data, missing keys are reported as errors
+func renderTemplate(name string, text string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Funcs(cmdutil.TemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// readTemplates reads issue templates from YAML or JSON file, or standard input when file is "-".
+// File is rendered as Go template with given variables before it is parsed. It holds single issue or list of issues
+func readTemplates(file string, vars map[string]string) ([]jiraApi.IssueTemplate, error) {
+	var content []byte
+	var err error
+	if file == "-" {
+		content, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		content, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	text, err := renderTemplate(file, string(content), vars)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := yaml.Unmarshal([]byte(text), &document); err != nil {
+		return nil, fmt.Errorf("invalid issue template %s: %w", file, err)
+	}
+	templates := make([]jiraApi.IssueTemplate, 0)
+	if _, isList := document.([]interface{}); isList {
+		err = yaml.UnmarshalStrict([]byte(text), &templates)
+	} else {
+		single := jiraApi.IssueTemplate{}
+		err = yaml.UnmarshalStrict([]byte(text), &single)
+		templates = append(templates, single)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue template %s: %w", file, err)
+	}
+	return templates, nil
+}
+
+// setTemplateDefaults sets project and issue type of templates which don't set them
+func setTemplateDefaults(templates []jiraApi.IssueTemplate, project string, issueType string) {
+	for i := range templates {
+		if templates[i].Project == "" {
+			templates[i].Project = project
+		}
+		if templates[i].Type == "" {
+			templates[i].Type = issueType
+		}
+	}
+}
+
+// previewTemplates prints fields of issues which would be created from templates. Sub-tasks refer to
+// their parents by position in report
+func previewTemplates(ctx context.Context, templates []jiraApi.IssueTemplate) {
+	previews := make([]previewIssue, 0)
+	table := cmdutil.Table{Header: []string{"#", "PARENT", "PROJECT", "TYPE", "SUMMARY"}}
+	add := func(t jiraApi.IssueTemplate, parent string) {
+		fields, err := jiraApi.TemplateFields(ctx, t)
+		cmdutil.CheckErr(err)
+		previews = append(previews, previewIssue{Parent: parent, Fields: fields})
+		table.Append(strconv.Itoa(len(previews)), parent, t.Project, t.Type, t.Summary)
+	}
+	for _, t := range templates {
+		add(t, "")
+		parent := "#" + strconv.Itoa(len(previews))
+		for _, s := range t.Subtasks {
+			add(t.Subtask(s, ""), parent)
+		}
+	}
+	cmdutil.Print(previews, table)
+}
+
+// createFromTemplates creates issues from templates and prints report. It exits with cmdutil.ExitFailed
+// when any issue or link was not created
+func createFromTemplates(ctx context.Context, templates []jiraApi.IssueTemplate) {
+	results := jiraApi.CreateFromTemplates(ctx, templates)
+	created := make([]createdIssue, 0, len(results))
+	table := cmdutil.Table{Header: []string{"KEY", "PARENT", "SUMMARY", "STATUS", "ERROR"}}
+	failed := false
+	for _, r := range results {
+		issue := createdIssue{Summary: r.Summary, Parent: r.Parent, Key: r.Issue.Key, Self: r.Issue.Self, Status: "created"}
+		if r.Err != nil {
+			cmdutil.PrintError(r.Err)
+			issue.Status, issue.Error = "failed", r.Err.Error()
+			failed = true
+		} else {
+			logrus.Infof("Created key: %s %s\n", r.Issue.Key, r.Issue.Self)
+		}
+		created = append(created, issue)
+		table.Append(issue.Key, issue.Parent, issue.Summary, issue.Status, issue.Error)
+	}
+	cmdutil.Print(created, table)
+	if failed {
+		os.Exit(cmdutil.ExitFailed)
+	}
+}
@@ -17,8 +17,8 @@ package transition
 
 import (
 	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
-	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -30,11 +30,10 @@ var testWorkflowCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		workflow, err := cmd.Flags().GetString("workflow")
-		if err != nil {
-			logrus.Errorln(err)
-			os.Exit(1)
-		}
-		jiraApi.TestTransitions(workflow, args[0])
+		cmdutil.CheckErr(err)
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		cmdutil.CheckErr(jiraApi.TestTransitions(ctx, workflow, args[0]))
 		logrus.Infoln("issueTransitionTest PASSED")
 	},
 }
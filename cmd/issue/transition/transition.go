@@ -16,10 +16,10 @@
 package transition
 
 import (
-	"github.com/sirupsen/logrus"
+	"errors"
+	"fmt"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
-	"os"
-	"sync"
 
 	"github.com/spf13/cobra"
 )
@@ -35,21 +35,23 @@ var TransitionCmd = &cobra.Command{
 		issueKeys := args[1:]
 		workflow, err := cmd.Flags().GetString("workflow")
 		exclude, _ := cmd.Flags().GetString("exclude")
-		if err != nil {
-			logrus.Errorln(err)
-			os.Exit(1)
-		}
-		var wg sync.WaitGroup
-		for _, issueKey := range issueKeys {
-			wg.Add(1)
-			go func(workflow string, issueKey string, targetState string) {
-				defer wg.Done()
-				if _, err := jiraApi.TransitionIssue(workflow, issueKey, targetState, exclude); err != nil {
-					logrus.Errorln(err)
-				}
-			}(workflow, issueKey, targetState)
-		}
-		wg.Wait()
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		cmdutil.CheckErr(err)
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		ctx, progress := cmdutil.NewProgress(ctx, issueKeys, failFast)
+		jiraApi.ForEach(ctx, issueKeys, func(issueKey string) {
+			_, err := jiraApi.TransitionIssue(ctx, workflow, issueKey, targetState, exclude)
+			switch {
+			case errors.Is(err, jiraApi.ErrExcludedStatus):
+				progress.Skip(issueKey, err.Error())
+			case err != nil:
+				progress.Done(issueKey, err)
+			default:
+				progress.Succeed(issueKey, fmt.Sprintf("status %s", targetState))
+			}
+		})
+		progress.Finish(ctx)
 	},
 }
 
@@ -57,4 +59,5 @@ func init() {
 	TransitionCmd.AddCommand(testWorkflowCmd)
 	TransitionCmd.Flags().StringP("workflow", "w", "workflow.yaml", "WorkflowTransitionsMap definition local file or http URL")
 	TransitionCmd.Flags().StringP("exclude", "e", "", "Exclude issues in given status")
+	TransitionCmd.Flags().Bool("fail-fast", false, "Stop transitioning remaining issues after first failure")
 }
@@ -16,11 +16,12 @@
 package version
 
 import (
-	"github.com/sirupsen/logrus"
+	"errors"
+	"fmt"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
 	"github.com/spf13/cobra"
 	"strings"
-	"sync"
 )
 
 var (
@@ -29,6 +30,7 @@ var (
 	issueType string
 	create bool
 	deployment bool
+	failFast bool
 )
 
 // VersionCmd represents the issueVersion command
@@ -44,24 +46,25 @@ If version does not exist it will be created`,
 		version := args[0]
 		issueKeys := args[1:]
 		projectKey := strings.Split(issueKeys[0], "-")[0]
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
 		if create {
-			err := jiraApi.CreateFixVersion(projectKey, version, deployment, summary, description, issueType)
-			if err != nil {
-				logrus.Fatal(err)
-			}
-		}
-		var wg sync.WaitGroup
-		for _, issueKey := range issueKeys {
-			wg.Add(1)
-			go func(issueKey string, version string) {
-				defer wg.Done()
-				err := jiraApi.SetFixVersion(issueKey, version)
-				if err == nil {
-					logrus.Infof("Success version %s set for issue %s\n", version, issueKey)
-				}
-			}(issueKey, version)
+			err := jiraApi.CreateFixVersion(ctx, projectKey, version, deployment, summary, description, issueType)
+			cmdutil.CheckErr(err)
 		}
-		wg.Wait()
+		ctx, progress := cmdutil.NewProgress(ctx, issueKeys, failFast)
+		jiraApi.ForEach(ctx, issueKeys, func(issueKey string) {
+			err := jiraApi.SetFixVersion(ctx, issueKey, version)
+			switch {
+			case errors.Is(err, jiraApi.ErrFixVersionSet):
+				progress.Skip(issueKey, err.Error())
+			case err != nil:
+				progress.Done(issueKey, err)
+			default:
+				progress.Succeed(issueKey, fmt.Sprintf("fix version %s set", version))
+			}
+		})
+		progress.Finish(ctx)
 	},
 }
 
@@ -71,4 +74,5 @@ func init() {
 	VersionCmd.Flags().StringVarP(&issueType, "issue-type", "t", "", "Deployment issue type.")
 	VersionCmd.Flags().BoolVarP(&create, "create", "c", true, "Create version if not exists.")
 	VersionCmd.Flags().BoolVarP(&deployment, "create-deployment-issue", "i", true, "Create deployment issue for version if not exists.")
+	VersionCmd.Flags().BoolVar(&failFast, "fail-fast", false, "Stop setting version of remaining issues after first failure.")
 }
@@ -21,10 +21,11 @@
 package worklog
 
 import (
+	"fmt"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
 	"github.com/spf13/cobra"
 	"strconv"
-	"sync"
 )
 
 //Cmd workload add command
@@ -39,6 +40,7 @@ var worklogCreateCmd = &cobra.Command{
 		com, err := cmd.Flags().GetString("comment")
 		date, _ := cmd.Flags().GetString("date")
 		time, _ := cmd.Flags().GetString("time")
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
 
 		if err != nil || len(com) == 0 {
 			com = `Automatically added by jira-cli. 
@@ -48,15 +50,18 @@ var worklogCreateCmd = &cobra.Command{
 			`
 		}
 
-		var wg sync.WaitGroup
-		for _, issueKey := range issueKeys {
-			wg.Add(1)
-			go func(issueKey string, min uint64, com string, date string, time string) {
-				defer wg.Done()
-				jiraApi.AddWorklog(issueKey, min, com, date, time)
-			}(issueKey, min, com, date, time)
-		}
-		wg.Wait()
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		ctx, progress := cmdutil.NewProgress(ctx, issueKeys, failFast)
+		jiraApi.ForEach(ctx, issueKeys, func(issueKey string) {
+			_, err := jiraApi.AddWorklog(ctx, issueKey, min, com, date, time)
+			if err != nil {
+				progress.Done(issueKey, err)
+				return
+			}
+			progress.Succeed(issueKey, fmt.Sprintf("%dm logged", min))
+		})
+		progress.Finish(ctx)
 	},
 }
 
@@ -64,5 +69,6 @@ func init() {
 	worklogCreateCmd.Flags().StringP("comment", "c", "", "Comment for worklog entry.")
 	worklogCreateCmd.Flags().StringP("date", "d", "", "Explicit date for worklog entry.\nMust adhere to format: YYYY-MM-DD (eg. 2019-04-01).\n[ Default: current date ]")
 	worklogCreateCmd.Flags().StringP("time", "t", "", "Explicit time for worklog entry.\nMust adhere to format: HH:ss (eg. 12:30).\n[ Default: 08:00 ]")
+	worklogCreateCmd.Flags().Bool("fail-fast", false, "Stop adding worklogs to remaining issues after first failure.")
 
 }
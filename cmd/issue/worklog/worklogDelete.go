@@ -21,11 +21,10 @@
 package worklog
 
 import (
-	"github.com/sirupsen/logrus"
+	"fmt"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"sync"
 )
 
 //Cmd workload add command
@@ -35,28 +34,30 @@ var worklogDeleteCmd = &cobra.Command{
 	Args:    cobra.MinimumNArgs(1),
 	Short:   "Delete all worklogs for logged user from provided ISSUE_KEY",
 	Run: func(cmd *cobra.Command, args []string) {
-		user := viper.GetString("JIRA_USER")
 		issueKeys := args
-		var wg sync.WaitGroup
-		for _, issueKey := range issueKeys {
-			wg.Add(1)
-			go func(user string, issueKey string) {
-				defer wg.Done()
-				sumOk, sumError, err := jiraApi.DeleteWorklogForUser(user, issueKey)
-				if err != nil {
-					logrus.Errorln(err)
-				}
-
-				if sumError == 0 && sumOk == 0 {
-					logrus.Infof("There was no worklogs for user %s in issue %s.", user, issueKey)
-				} else {
-					logrus.Infof("%s Success: %d | Failed: %d", issueKey, sumOk, sumError)
-				}
-			} (user, issueKey)
-		}
-		wg.Wait()
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		user, err := jiraApi.CurrentUserId(ctx)
+		cmdutil.CheckErr(err)
+		ctx, progress := cmdutil.NewProgress(ctx, issueKeys, failFast)
+		jiraApi.ForEach(ctx, issueKeys, func(issueKey string) {
+			sumOk, sumError, err := jiraApi.DeleteWorklogForUser(ctx, user, issueKey)
+			switch {
+			case err != nil:
+				progress.Done(issueKey, err)
+			case sumError > 0:
+				progress.Done(issueKey, fmt.Errorf("%d of %d worklogs not deleted", sumError, sumOk+sumError))
+			case sumOk == 0:
+				progress.Skip(issueKey, fmt.Sprintf("no worklogs of user %s", user))
+			default:
+				progress.Succeed(issueKey, fmt.Sprintf("%d worklogs deleted", sumOk))
+			}
+		})
+		progress.Finish(ctx)
 	},
 }
 
 func init() {
+	worklogDeleteCmd.Flags().Bool("fail-fast", false, "Stop removing worklogs of remaining issues after first failure.")
 }
@@ -21,11 +21,10 @@
 package worklog
 
 import (
-	"github.com/olekukonko/tablewriter"
 	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
 	"github.com/spf13/cobra"
-	"os"
 	"strconv"
 )
 
@@ -37,24 +36,25 @@ var worklogListCmd = &cobra.Command{
 	Short:   "List worklog for given task",
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		resp, err := jiraApi.ListWorklog(key)
+		limit, _ := cmd.Flags().GetInt("limit")
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		resp, err := jiraApi.ListWorklog(ctx, key, limit)
 		if err != nil {
 			logrus.Errorf("There was an error while listing worklogs for issue %s.", key)
-			os.Exit(1)
+			cmdutil.CheckErr(err)
 		}
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"ID", "AUTHOR", "TIME [m]"})
-		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
-		table.SetCenterSeparator("|")
+		table := cmdutil.Table{Header: []string{"ID", "AUTHOR", "TIME [m]"}}
 		sum := 0
 		for _, p := range resp.Worklogs {
 			sum += p.TimeSpent
-			table.Append([]string{p.Id, p.Author.Name, strconv.Itoa(p.TimeSpent / 60)})
+			table.Append(p.Id, p.Author.String(), strconv.Itoa(p.TimeSpent/60))
 		}
-		table.SetFooter([]string{"", "Total", strconv.Itoa(sum / 60)})
-		table.Render()
+		table.Footer = []string{"", "Total", strconv.Itoa(sum / 60)}
+		cmdutil.PrintItems(resp, resp.Worklogs, table)
 	},
 }
 
 func init() {
+	worklogListCmd.Flags().IntP("limit", "l", 0, "Maximal number of listed worklogs, 0 means no limit")
 }
@@ -17,13 +17,14 @@ package cmd
 
 import (
 	"bufio"
-	"encoding/json"
+	"context"
 	"fmt"
 	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
+	"github.com/sotomskir/jira-cli/jiraApi"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"golang.org/x/crypto/ssh/terminal"
-	"io/ioutil"
 	"net/http"
 	"os"
 	"strings"
@@ -36,30 +37,104 @@ var loginCmd = &cobra.Command{
 	Use:     "login",
 	Aliases: []string{"l"},
 	Short:   "Login to Atlassian Jira server",
+	Long: `Login to Atlassian Jira server.
+Supported authentication types are:
+  basic        user name and password
+  bearer       Personal Access Token of Jira Data Center
+  cloud-token  account e-mail and API token of Jira Cloud
+  oauth1       OAuth 1.0a application link with RSA key
+Server and authentication are saved in profile selected with --profile flag,
+by default in profile of the server, see jira-cli context. Profile becomes current.
+Secrets are kept in Secret Service keyring, passphrase encrypted file or external credential helper,
+config file only refers to the store. Plain text secrets saved by previous versions are moved to the store.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		profiles := savedProfiles()
+		name := viper.GetString("JIRA_PROFILE")
 		server := viper.GetString("JIRA_SERVER_URL")
+		if p := findProfile(profiles, name); server == "" && p != nil {
+			server = p.Url
+		}
 		if server == "" {
 			server = getInput("JIRA server URL: ")
-			viper.Set("JIRA_SERVER_URL", server)
 		}
-		user := viper.GetString("JIRA_USER")
-		if user == "" {
-			user = getInput("Username: ")
-			viper.Set("JIRA_USER", user)
+		server = strings.TrimRight(server, "/")
+		// settings of profile are defaults, secrets are asked again
+		defaults := profile{}
+		if p := findProfile(profiles, name); p != nil {
+			defaults = profile{Auth: p.Auth, User: p.User, ConsumerKey: p.ConsumerKey, PrivateKey: p.PrivateKey}
 		}
-		password := viper.GetString("JIRA_PASSWORD")
-		if password == "" {
-			password = getPasswd()
-			//@FIXME password store in plain text
-			viper.Set("JIRA_PASSWORD", password)
+		config := authConfig(defaults)
+		config.Type = strings.ToLower(config.Type)
+		if config.Type == "" && config.Password != "" {
+			// password given with --password flag, as in non interactive login of previous versions
+			config.Type = jiraApi.AuthBasic
 		}
-
-		loggedIn := login(server, user, password)
-
-		if loggedIn {
-			viper.WriteConfig()
-			logrus.Infof("Success, Logged in to: %s as: %s\n", server, user)
+		if config.Type == "" {
+			config.Type = getInput(fmt.Sprintf("Authentication (%s) [%s]: ", strings.Join(jiraApi.AuthTypes, ", "), jiraApi.AuthBasic))
+			if config.Type == "" {
+				config.Type = jiraApi.AuthBasic
+			}
+		}
+		switch config.Type {
+		case jiraApi.AuthBasic:
+			if config.Username == "" {
+				config.Username = getInput("Username: ")
+			}
+			if config.Password == "" {
+				config.Password = getPasswd("Password: ")
+			}
+		case jiraApi.AuthBearer:
+			if config.Token == "" {
+				config.Token = getPasswd("Personal access token: ")
+			}
+		case jiraApi.AuthCloudToken:
+			if config.Username == "" {
+				config.Username = getInput("E-mail: ")
+			}
+			if config.Token == "" {
+				config.Token = getPasswd("API token: ")
+			}
+		case jiraApi.AuthOAuth1:
+			if config.ConsumerKey == "" {
+				config.ConsumerKey = getInput("Consumer key: ")
+			}
+			if config.PrivateKeyFile == "" {
+				config.PrivateKeyFile = getInput("Private key file: ")
+			}
+			if config.AccessToken == "" {
+				accessToken, err := authorizeOAuth(ctx, server, config)
+				cmdutil.CheckErr(err)
+				config.AccessToken = accessToken
+			}
+		}
+		auth, err := jiraApi.NewAuthenticator(config)
+		cmdutil.CheckErr(err)
+
+		user, err := login(ctx, server, config, auth)
+		cmdutil.CheckErr(err)
+
+		store, err := credentialStore(viper.GetString("JIRA_CREDENTIAL_STORE"))
+		cmdutil.CheckErr(err)
+		if name == "" {
+			if p := findProfileByUrl(profiles, server); p != nil {
+				name = p.Name
+			} else {
+				name = profileName(profiles, server)
+			}
+		}
+		p := findProfile(profiles, name)
+		if p == nil {
+			profiles = append(profiles, profile{Name: name})
+			p = &profiles[len(profiles)-1]
 		}
+		p.Url = server
+		setAuthConfig(p, config)
+		if err := writeProfiles(profiles, name, store); err != nil {
+			cmdutil.CheckErr(fmt.Errorf("cannot save config file: %w", err))
+		}
+		logrus.Infof("Success, Logged in to: %s as: %s, profile %s saved, credentials saved in %s store\n", server, user, name, store.Name())
 	},
 }
 
@@ -75,70 +150,64 @@ func init() {
 	// is called directly, e.g.:
 	// loginCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 	loginCmd.Flags().StringP("server", "s", "", "Jira server url. Also read from JIRA_SERVER_URL env variable")
-	loginCmd.Flags().StringP("user", "u", "", "Jira username. Also read from JIRA_USER env variable")
+	loginCmd.Flags().StringP("user", "u", "", "Jira username, or account e-mail for cloud-token. Also read from JIRA_USER env variable")
 	loginCmd.Flags().StringP("password", "p", "", "Jira password. Also read from JIRA_PASSWORD env variable")
+	loginCmd.Flags().String("auth", "", "Authentication type: basic, bearer, cloud-token or oauth1. Also read from JIRA_AUTH_TYPE env variable")
+	loginCmd.Flags().String("token", "", "Personal Access Token or Jira Cloud API token. Also read from JIRA_TOKEN env variable")
+	loginCmd.Flags().String("consumer-key", "", "OAuth consumer key of application link. Also read from JIRA_OAUTH_CONSUMER_KEY env variable")
+	loginCmd.Flags().String("private-key", "", "PEM file with RSA private key of OAuth application link. Also read from JIRA_OAUTH_PRIVATE_KEY env variable")
+	loginCmd.Flags().String("access-token", "", "Authorized OAuth access token, skips authorization in browser. Also read from JIRA_OAUTH_ACCESS_TOKEN env variable")
+	loginCmd.Flags().String("credential-store", "auto", "Where secrets are saved: keyring, file, helper or auto. Also read from JIRA_CREDENTIAL_STORE env variable")
 	viper.BindPFlag("JIRA_SERVER_URL", loginCmd.Flags().Lookup("server"))
 	viper.BindPFlag("JIRA_USER", loginCmd.Flags().Lookup("user"))
 	viper.BindPFlag("JIRA_PASSWORD", loginCmd.Flags().Lookup("password"))
+	viper.BindPFlag("JIRA_AUTH_TYPE", loginCmd.Flags().Lookup("auth"))
+	viper.BindPFlag("JIRA_TOKEN", loginCmd.Flags().Lookup("token"))
+	viper.BindPFlag("JIRA_OAUTH_CONSUMER_KEY", loginCmd.Flags().Lookup("consumer-key"))
+	viper.BindPFlag("JIRA_OAUTH_PRIVATE_KEY", loginCmd.Flags().Lookup("private-key"))
+	viper.BindPFlag("JIRA_OAUTH_ACCESS_TOKEN", loginCmd.Flags().Lookup("access-token"))
+	viper.BindPFlag("JIRA_CREDENTIAL_STORE", loginCmd.Flags().Lookup("credential-store"))
 }
 
 func getInput(prompt string) string {
 	reader := bufio.NewReader(os.Stdin)
 	fmt.Print(prompt)
 	text, _ := reader.ReadString('\n')
-	return strings.Trim(text, "\n")
+	return strings.TrimSpace(text)
 }
 
-func getPasswd() string {
-	fmt.Print("Password: ")
+func getPasswd(prompt string) string {
+	fmt.Print(prompt)
 	bytePassword, _ := terminal.ReadPassword(int(syscall.Stdin))
 	fmt.Println()
 	return string(bytePassword)
 }
 
-// JiraUser type represents response from session resource
-type JiraUser struct {
-	Name string `json:"name"`
-}
-
-func login(server string, user string, password string) bool {
-	url := server + "/rest/auth/1/session"
-
-	httpClient := http.Client{
-		Timeout: time.Second * 30,
-	}
-
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// authorizeOAuth asks user to authorize jira-cli in browser and returns OAuth access token
+func authorizeOAuth(ctx context.Context, server string, config jiraApi.AuthConfig) (string, error) {
+	oauth, err := jiraApi.NewOAuth1(config.ConsumerKey, config.PrivateKeyFile, "")
 	if err != nil {
-		logrus.Errorln(err)
-		os.Exit(1)
+		return "", err
 	}
-
-	req.SetBasicAuth(user, password)
-	res, getErr := httpClient.Do(req)
-	if getErr != nil {
-		logrus.Errorln(getErr)
-		os.Exit(1)
-	}
-
-	if res.StatusCode == 401 {
-		logrus.Errorln("Server responded with status: 401 Unauthorized")
-		os.Exit(1)
+	httpClient := &http.Client{
+		Timeout:   time.Second * 30,
+		Transport: transport(),
 	}
-
-	body, readErr := ioutil.ReadAll(res.Body)
-	if readErr != nil {
-		logrus.Errorln("Cannot read response body: " + readErr.Error())
-		os.Exit(1)
+	requestToken, authorizeUrl, err := oauth.RequestToken(ctx, httpClient, server)
+	if err != nil {
+		return "", err
 	}
+	fmt.Printf("Open following URL in browser and allow access:\n%s\n", authorizeUrl)
+	verifier := getInput("Verification code: ")
+	return oauth.AccessToken(ctx, httpClient, server, requestToken, verifier)
+}
 
-	jiraUser := JiraUser{}
-	jsonErr := json.Unmarshal(body, &jiraUser)
-	if jsonErr != nil {
-		logrus.Errorf("%s\n", body)
-		logrus.Errorln("Server responded invalid JSON")
-		os.Exit(1)
+// login checks credentials by reading authenticated user and returns its name
+func login(ctx context.Context, server string, config jiraApi.AuthConfig, auth jiraApi.Authenticator) (string, error) {
+	client := jiraApi.NewClient(server, config.Username, config.Password, append(clientOptions(), jiraApi.WithAuthenticator(auth))...)
+	user, err := client.Myself(ctx)
+	if err != nil {
+		return "", fmt.Errorf("cannot login: %w", err)
 	}
-
-	return true
+	return user.String(), nil
 }
@@ -0,0 +1,216 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"fmt"
+	"github.com/sotomskir/jira-cli/credentials"
+	"github.com/spf13/viper"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// profile type represents named JIRA server with authentication settings saved by login.
+// Secret is kept in credential store named by Store, configs written by previous versions keep it in plain text
+type profile struct {
+	Name        string `mapstructure:"name"`
+	Url         string `mapstructure:"url"`
+	Auth        string `mapstructure:"auth"`
+	User        string `mapstructure:"user"`
+	Password    string `mapstructure:"password"`
+	Token       string `mapstructure:"token"`
+	ConsumerKey string `mapstructure:"consumer_key"`
+	PrivateKey  string `mapstructure:"private_key"`
+	AccessToken string `mapstructure:"access_token"`
+	Store       string `mapstructure:"store"`
+}
+
+// savedProfiles returns profiles saved in config file.
+// Servers saved by previous versions, in top level settings or in jira_servers list, are returned as profiles named after host
+func savedProfiles() []profile {
+	profiles := make([]profile, 0)
+	viper.UnmarshalKey("JIRA_PROFILES", &profiles)
+	legacy := make([]profile, 0)
+	viper.UnmarshalKey("JIRA_SERVERS", &legacy)
+	if p, ok := legacyProfile(); ok {
+		legacy = append(legacy, p)
+	}
+	for _, p := range legacy {
+		if findProfileByUrl(profiles, p.Url) == nil {
+			p.Name = profileName(profiles, p.Url)
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles
+}
+
+// legacyProfile returns server saved in top level of config file by versions without profiles
+func legacyProfile() (profile, bool) {
+	config := configFileSettings()
+	if config.GetString("JIRA_SERVER_URL") == "" {
+		return profile{}, false
+	}
+	p := profile{Url: config.GetString("JIRA_SERVER_URL")}
+	if config.IsSet("JIRA_PASSWORD") {
+		p.Auth = "basic"
+		p.User = config.GetString("JIRA_USER")
+		p.Password = config.GetString("JIRA_PASSWORD")
+	}
+	return p, true
+}
+
+// configFileSettings returns settings saved in config file, without flags and ENV variables
+func configFileSettings() *viper.Viper {
+	config := viper.New()
+	config.SetConfigType("yaml")
+	file, err := configFile()
+	if err != nil {
+		return config
+	}
+	if _, err := os.Stat(file); err == nil {
+		config.SetConfigFile(file)
+		config.ReadInConfig()
+	}
+	return config
+}
+
+// findProfile returns profile of given name or nil
+func findProfile(profiles []profile, name string) *profile {
+	for i := range profiles {
+		if profiles[i].Name == name {
+			return &profiles[i]
+		}
+	}
+	return nil
+}
+
+// findProfileByUrl returns first profile of server or nil
+func findProfileByUrl(profiles []profile, server string) *profile {
+	for i := range profiles {
+		if sameServer(profiles[i].Url, server) {
+			return &profiles[i]
+		}
+	}
+	return nil
+}
+
+// profileName returns unique name of new profile for server, derived from its host
+func profileName(profiles []profile, server string) string {
+	name := server
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		name = u.Host
+	}
+	unique := name
+	for i := 2; findProfile(profiles, unique) != nil; i++ {
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	return unique
+}
+
+// activeProfile returns profile selected with --profile flag or JIRA_PROFILE, which is set by context use.
+// Without selected profile, profile of JIRA_SERVER_URL is used
+func activeProfile() (profile, error) {
+	profiles := savedProfiles()
+	if name := viper.GetString("JIRA_PROFILE"); name != "" {
+		if p := findProfile(profiles, name); p != nil {
+			return *p, nil
+		}
+		return profile{Name: name}, fmt.Errorf("unknown profile: %s, see jira-cli context list", name)
+	}
+	server := viper.GetString("JIRA_SERVER_URL")
+	if p := findProfileByUrl(profiles, server); server != "" && p != nil {
+		return *p, nil
+	}
+	return profile{Url: server}, nil
+}
+
+// currentProfile returns name of profile saved as current in config file
+func currentProfile() string {
+	if name := configFileSettings().GetString("JIRA_PROFILE"); name != "" {
+		return name
+	}
+	if legacy, ok := legacyProfile(); ok {
+		if p := findProfileByUrl(savedProfiles(), legacy.Url); p != nil {
+			return p.Name
+		}
+	}
+	return ""
+}
+
+// serverUrl returns JIRA server URL of profile, JIRA_SERVER_URL takes precedence
+func serverUrl(p profile) string {
+	if server := viper.GetString("JIRA_SERVER_URL"); server != "" {
+		return server
+	}
+	return p.Url
+}
+
+// writeProfiles saves profiles and name of current profile in config file. When store is set, plain text secrets
+// are moved to it. Servers saved by previous versions are removed, as they are included in profiles
+func writeProfiles(profiles []profile, current string, store credentials.Store) error {
+	setting := make([]map[string]interface{}, 0)
+	for i := range profiles {
+		if store != nil {
+			if err := storeSecret(store, &profiles[i]); err != nil {
+				return err
+			}
+		}
+		setting = append(setting, profileSetting(profiles[i]))
+	}
+	settings := map[string]interface{}{
+		"JIRA_PROFILES":   setting,
+		"JIRA_PROFILE":    nil,
+		"JIRA_SERVERS":    nil,
+		"JIRA_SERVER_URL": nil,
+		"JIRA_USER":       nil,
+		"JIRA_PASSWORD":   nil,
+	}
+	if current != "" {
+		settings["JIRA_PROFILE"] = current
+	}
+	file, err := configFile()
+	if err != nil {
+		return err
+	}
+	return writeConfig(file, settings)
+}
+
+// profileSetting returns non empty settings of profile
+func profileSetting(p profile) map[string]interface{} {
+	setting := make(map[string]interface{})
+	for key, value := range map[string]string{
+		"name":         p.Name,
+		"url":          p.Url,
+		"auth":         p.Auth,
+		"user":         p.User,
+		"password":     p.Password,
+		"token":        p.Token,
+		"consumer_key": p.ConsumerKey,
+		"private_key":  p.PrivateKey,
+		"access_token": p.AccessToken,
+		"store":        p.Store,
+	} {
+		if value != "" {
+			setting[key] = value
+		}
+	}
+	return setting
+}
+
+// sameServer reports whether URLs point to the same server
+func sameServer(a string, b string) bool {
+	return strings.EqualFold(strings.TrimRight(a, "/"), strings.TrimRight(b, "/"))
+}
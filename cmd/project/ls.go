@@ -16,9 +16,8 @@
 package project
 
 import (
-	"github.com/olekukonko/tablewriter"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
-	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -28,20 +27,22 @@ var projectLsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "List all projects",
 	Run: func(cmd *cobra.Command, args []string) {
-		projects := jiraApi.GetProjects()
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"ID", "KEY", "NAME"})
-		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
-		table.SetCenterSeparator("|")
+		limit, _ := cmd.Flags().GetInt("limit")
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		projects, err := jiraApi.GetProjects(ctx, limit)
+		cmdutil.CheckErr(err)
+		table := cmdutil.Table{Header: []string{"ID", "KEY", "NAME"}}
 		for _, p := range projects {
-			table.Append([]string{p.Id, p.Key, p.Name})
+			table.Append(p.Id, p.Key, p.Name)
 		}
-		table.Render() // Send output
+		cmdutil.Print(projects, table)
 	},
 }
 
 func init() {
 	Cmd.AddCommand(projectLsCmd)
+	projectLsCmd.Flags().IntP("limit", "l", 0, "Maximal number of listed projects, 0 means no limit")
 
 	// Here you will define your flags and configuration settings.
 
@@ -17,14 +17,19 @@ package cmd
 import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cache"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/cmd/issue"
 	"github.com/sotomskir/jira-cli/cmd/project"
 	"github.com/sotomskir/jira-cli/cmd/version"
 	"github.com/sotomskir/jira-cli/jiraApi"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"net/http"
 	"os"
 	"path"
+	"strings"
+	"time"
 )
 
 var cfgFile string
@@ -58,6 +63,9 @@ func init() {
 
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable ANSI color output")
+	viper.BindPFlag("JIRA_NO_COLOR", rootCmd.PersistentFlags().Lookup("no-color"))
+	rootCmd.PersistentFlags().String("profile", "", "Server profile used by command, default is current profile. Also read from JIRA_PROFILE")
+	viper.BindPFlag("JIRA_PROFILE", rootCmd.PersistentFlags().Lookup("profile"))
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
@@ -65,11 +73,71 @@ func init() {
 	//rootCmd.PersistentFlags().StringVarP(&password, "password", "p", "admin", "Jira password")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Debug output")
 	rootCmd.PersistentFlags().BoolVar(&trace, "trace", false, "Trace output")
+	rootCmd.PersistentFlags().Int("retry", 3, "Number of retries of requests rejected with HTTP 429, 502, 503 or 504. Also read from JIRA_RETRY")
+	rootCmd.PersistentFlags().Duration("retry-wait", 1*time.Second, "Base wait time of exponential retry backoff. Also read from JIRA_RETRY_WAIT")
+	rootCmd.PersistentFlags().Duration("retry-max-wait", 30*time.Second, "Maximum wait time of retry backoff. Also read from JIRA_RETRY_MAX_WAIT")
+	rootCmd.PersistentFlags().Bool("retry-non-idempotent", false, "Retry also POST requests. Also read from JIRA_RETRY_NON_IDEMPOTENT")
+	rootCmd.PersistentFlags().Int("concurrency", 10, "Maximal number of requests sent at the same time. Also read from JIRA_CONCURRENCY")
+	rootCmd.PersistentFlags().Float64("rate-limit", 0, "Maximal number of requests sent per second, 0 means no limit. Also read from JIRA_RATE_LIMIT")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "Cancel command running longer than given time, 0 means no timeout. Also read from JIRA_TIMEOUT")
+	viper.BindPFlag("JIRA_RETRY", rootCmd.PersistentFlags().Lookup("retry"))
+	viper.BindPFlag("JIRA_RETRY_WAIT", rootCmd.PersistentFlags().Lookup("retry-wait"))
+	viper.BindPFlag("JIRA_RETRY_MAX_WAIT", rootCmd.PersistentFlags().Lookup("retry-max-wait"))
+	viper.BindPFlag("JIRA_RETRY_NON_IDEMPOTENT", rootCmd.PersistentFlags().Lookup("retry-non-idempotent"))
+	viper.BindPFlag("JIRA_CONCURRENCY", rootCmd.PersistentFlags().Lookup("concurrency"))
+	viper.BindPFlag("JIRA_RATE_LIMIT", rootCmd.PersistentFlags().Lookup("rate-limit"))
+	rootCmd.PersistentFlags().Bool("cache", false, "Cache projects, versions, workflows and fields in user cache directory. Also read from JIRA_CACHE")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Disable cache enabled in config file. Also read from JIRA_NO_CACHE")
+	rootCmd.PersistentFlags().String("record", "", "Save every request and response to given cassette file with credentials redacted. Also read from JIRA_RECORD")
+	rootCmd.PersistentFlags().String("replay", "", "Respond to requests from given cassette file without network access. Also read from JIRA_REPLAY")
+	rootCmd.PersistentFlags().String("proxy", "", "HTTP(S) proxy URL, default is taken from HTTPS_PROXY and HTTP_PROXY. Also read from JIRA_PROXY")
+	rootCmd.PersistentFlags().StringSlice("ca-cert", nil, "PEM file with additional trusted CA certificates. Also read from JIRA_CA_CERT")
+	rootCmd.PersistentFlags().String("client-cert", "", "PEM file with client certificate. Also read from JIRA_CLIENT_CERT")
+	rootCmd.PersistentFlags().String("client-key", "", "PEM file with client certificate private key. Also read from JIRA_CLIENT_KEY")
+	rootCmd.PersistentFlags().Bool("insecure-skip-verify", false, "Do not verify server certificate, insecure. Also read from JIRA_INSECURE_SKIP_VERIFY")
+	rootCmd.PersistentFlags().StringP("output", "o", "table", "Output format: table, json, yaml, csv, tsv, template=TEXT or template-file=PATH. Also read from JIRA_OUTPUT")
+	rootCmd.PersistentFlags().String("template", "", "Go template used to print every result item, overrides --output. Also read from JIRA_TEMPLATE")
+	rootCmd.PersistentFlags().String("api-flavor", "auto", "REST API flavor: server (v2, user names), cloud (v3, account ids) or auto. Also read from JIRA_API_FLAVOR")
+	viper.BindPFlag("JIRA_TIMEOUT", rootCmd.PersistentFlags().Lookup("timeout"))
+	viper.BindPFlag("JIRA_CACHE", rootCmd.PersistentFlags().Lookup("cache"))
+	viper.BindPFlag("JIRA_NO_CACHE", rootCmd.PersistentFlags().Lookup("no-cache"))
+	viper.BindPFlag("JIRA_RECORD", rootCmd.PersistentFlags().Lookup("record"))
+	viper.BindPFlag("JIRA_REPLAY", rootCmd.PersistentFlags().Lookup("replay"))
+	viper.BindPFlag("JIRA_PROXY", rootCmd.PersistentFlags().Lookup("proxy"))
+	viper.BindPFlag("JIRA_CA_CERT", rootCmd.PersistentFlags().Lookup("ca-cert"))
+	viper.BindPFlag("JIRA_CLIENT_CERT", rootCmd.PersistentFlags().Lookup("client-cert"))
+	viper.BindPFlag("JIRA_CLIENT_KEY", rootCmd.PersistentFlags().Lookup("client-key"))
+	viper.BindPFlag("JIRA_INSECURE_SKIP_VERIFY", rootCmd.PersistentFlags().Lookup("insecure-skip-verify"))
+	viper.BindPFlag("JIRA_API_FLAVOR", rootCmd.PersistentFlags().Lookup("api-flavor"))
+	viper.BindPFlag("JIRA_OUTPUT", rootCmd.PersistentFlags().Lookup("output"))
+	viper.BindPFlag("JIRA_TEMPLATE", rootCmd.PersistentFlags().Lookup("template"))
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if requiresProfile(cmd) {
+			_, err := activeProfile()
+			cmdutil.CheckErr(err)
+		}
+	}
 	rootCmd.AddCommand(issue.Cmd)
 	rootCmd.AddCommand(version.Cmd)
 	rootCmd.AddCommand(project.Cmd)
+	rootCmd.AddCommand(cache.Cmd)
+}
+
+// requiresProfile returns false for commands which don't call JIRA API or which fix selected profile,
+// so they work when saved profile no longer exists
+func requiresProfile(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c {
+		case loginCmd, contextCmd, completionCmd, docsCmd, cache.Cmd:
+			return false
+		}
+		if c.Name() == "help" {
+			return false
+		}
+	}
+	return true
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -114,7 +182,137 @@ func initConfig() {
 			logrus.Errorln(err)
 			os.Exit(1)
 		}
-		viper.WriteConfigAs(path.Join(home, "/.jira-cli.yaml"))
+		writeConfig(path.Join(home, "/.jira-cli.yaml"), nil)
+	}
+	cmdutil.CheckErr(cmdutil.CheckOutputFormat())
+	// unknown profile is reported before commands which require it are run, see requiresProfile
+	p, _ := activeProfile()
+	auth := authConfig(p)
+	jiraApi.Initialize(serverUrl(p), auth.Username, auth.Password, append(clientOptions(), jiraApi.WithAuthenticator(authenticator()))...)
+}
+
+// writeConfig stores given settings in config file and keeps settings already saved in it.
+// Setting with nil value is removed from file.
+// Values of flags and ENV variables are not written, so options of single run like --replay are never persisted
+func writeConfig(file string, settings map[string]interface{}) error {
+	saved := viper.New()
+	saved.SetConfigType("yaml")
+	saved.SetConfigFile(file)
+	if _, err := os.Stat(file); err == nil {
+		if err := saved.ReadInConfig(); err != nil {
+			return err
+		}
+	}
+	all := saved.AllSettings()
+	for key, value := range settings {
+		if value == nil {
+			delete(all, strings.ToLower(key))
+		} else {
+			all[strings.ToLower(key)] = value
+		}
+	}
+	config := viper.New()
+	config.SetConfigType("yaml")
+	for key, value := range all {
+		config.Set(key, value)
+	}
+	return config.WriteConfigAs(file)
+}
+
+// configFile returns path of config file in use or default config file path
+func configFile() (string, error) {
+	if file := viper.ConfigFileUsed(); file != "" {
+		return file, nil
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(home, "/.jira-cli.yaml"), nil
+}
+
+var sharedTransport *http.Transport
+
+// transport returns HTTP transport with proxy and TLS settings read from flags, config file and ENV variables.
+// It is shared by API client and login check
+func transport() *http.Transport {
+	if sharedTransport != nil {
+		return sharedTransport
+	}
+	config := jiraApi.TransportConfig{
+		Proxy:              viper.GetString("JIRA_PROXY"),
+		CACertFiles:        viper.GetStringSlice("JIRA_CA_CERT"),
+		ClientCertFile:     viper.GetString("JIRA_CLIENT_CERT"),
+		ClientKeyFile:      viper.GetString("JIRA_CLIENT_KEY"),
+		InsecureSkipVerify: viper.GetBool("JIRA_INSECURE_SKIP_VERIFY"),
+	}
+	if config.InsecureSkipVerify {
+		logrus.Warnln("Server certificate verification is disabled")
+	}
+	t, err := jiraApi.NewTransport(config)
+	if err != nil {
+		logrus.Errorln(err)
+		os.Exit(1)
+	}
+	sharedTransport = t
+	return sharedTransport
+}
+
+// clientOptions returns API client options read from flags, config file and ENV variables
+func clientOptions() []jiraApi.Option {
+	flavor, err := jiraApi.ParseFlavor(viper.GetString("JIRA_API_FLAVOR"))
+	if err != nil {
+		logrus.Errorln(err)
+		os.Exit(1)
+	}
+	options := []jiraApi.Option{
+		jiraApi.WithTransport(transport()),
+		jiraApi.WithFlavor(flavor),
+		jiraApi.WithRetryPolicy(jiraApi.RetryPolicy{
+			MaxRetries:         viper.GetInt("JIRA_RETRY"),
+			WaitTime:           viper.GetDuration("JIRA_RETRY_WAIT"),
+			MaxWaitTime:        viper.GetDuration("JIRA_RETRY_MAX_WAIT"),
+			RetryNonIdempotent: viper.GetBool("JIRA_RETRY_NON_IDEMPOTENT"),
+		}),
+		jiraApi.WithConcurrency(viper.GetInt("JIRA_CONCURRENCY")),
+		jiraApi.WithRateLimit(viper.GetFloat64("JIRA_RATE_LIMIT")),
+	}
+	record := viper.GetString("JIRA_RECORD")
+	replay := viper.GetString("JIRA_REPLAY")
+	switch {
+	case record != "" && replay != "":
+		logrus.Errorln("--record and --replay can not be used together")
+		os.Exit(1)
+	case record != "":
+		// responses are not taken from cache, so every request is recorded
+		return append(options, jiraApi.WithRecorder(record))
+	case replay != "":
+		cassette, err := jiraApi.LoadCassette(replay)
+		if err != nil {
+			logrus.Errorln(err)
+			os.Exit(1)
+		}
+		return append(options, jiraApi.WithReplay(cassette))
+	}
+	if viper.GetBool("JIRA_CACHE") && !viper.GetBool("JIRA_NO_CACHE") {
+		dir, err := jiraApi.DefaultCacheDir()
+		if err != nil {
+			logrus.Warnf("Cache disabled: %s\n", err)
+			return options
+		}
+		options = append(options, jiraApi.WithCache(jiraApi.NewCache(dir, cacheTTL())))
+	}
+	return options
+}
+
+// cacheTTL returns cache lifetime of resource types set in config file or ENV variables
+func cacheTTL() map[string]time.Duration {
+	ttl := make(map[string]time.Duration)
+	for _, kind := range []string{jiraApi.CacheProjects, jiraApi.CacheVersions, jiraApi.CacheWorkflows, jiraApi.CacheFields} {
+		key := "JIRA_CACHE_TTL_" + strings.ToUpper(kind)
+		if viper.IsSet(key) {
+			ttl[kind] = viper.GetDuration(key)
+		}
 	}
-	jiraApi.Initialize(viper.GetString("JIRA_SERVER_URL"), viper.GetString("JIRA_USER"), viper.GetString("JIRA_PASSWORD"))
+	return ttl
 }
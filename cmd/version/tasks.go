@@ -16,10 +16,9 @@
 package version
 
 import (
-	"github.com/olekukonko/tablewriter"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
 	"github.com/spf13/cobra"
-	"os"
 	"strconv"
 )
 
@@ -33,22 +32,23 @@ var tasksCmd = &cobra.Command{
 		projectKey := args[0]
 		version := args[1]
 		issueTypes := args[2]
-		response, _ := jiraApi.GetIssuesInVersions(projectKey, version, issueTypes)
+		limit, _ := cmd.Flags().GetInt("limit")
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		response, err := jiraApi.GetIssuesInVersions(ctx, projectKey, version, issueTypes, limit)
+		cmdutil.CheckErr(err)
 
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"KEY", "SUMMARY"})
-		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
-		table.SetCenterSeparator("|")
+		table := cmdutil.Table{Header: []string{"KEY", "SUMMARY"}, Footer: []string{"Total", strconv.Itoa(response.Total)}}
 		for _, p := range response.Issues {
-			table.Append([]string{p.Key, p.Fields.Summary})
+			table.Append(p.Key, p.Fields.Summary)
 		}
-		table.SetFooter([]string{"Total", strconv.Itoa(response.Total)})
-		table.Render()
+		cmdutil.PrintItems(response, response.Issues, table)
 	},
 }
 
 func init() {
 	Cmd.AddCommand(tasksCmd)
+	tasksCmd.Flags().IntP("limit", "l", 0, "Maximal number of listed issues, 0 means no limit")
 
 	// Here you will define your flags and configuration settings.
 
@@ -17,6 +17,7 @@ package version
 
 import (
 	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
 	"github.com/spf13/cobra"
 )
@@ -30,7 +31,10 @@ var versionCreateCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		version := args[0]
 		projectKey := args[1]
-		response, _, _ := jiraApi.CreateVersion(projectKey, version)
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		response, _, err := jiraApi.CreateVersion(ctx, projectKey, version)
+		cmdutil.CheckErr(err)
 		logrus.Infof("Success version created %#v\n", response)
 	},
 }
@@ -17,10 +17,9 @@ package version
 
 import (
 	"fmt"
-	"github.com/olekukonko/tablewriter"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
 	"github.com/spf13/cobra"
-	"os"
 )
 
 // versionLsCmd represents the versionLs command
@@ -30,20 +29,22 @@ var versionLsCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectKey := args[0]
-		versions := jiraApi.GetVersions(projectKey)
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"ID", "NAME", "ARCHIVED", "RELEASED", "PROJECT ID"})
-		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
-		table.SetCenterSeparator("|")
+		limit, _ := cmd.Flags().GetInt("limit")
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		versions, err := jiraApi.GetVersions(ctx, projectKey, limit)
+		cmdutil.CheckErr(err)
+		table := cmdutil.Table{Header: []string{"ID", "NAME", "ARCHIVED", "RELEASED", "PROJECT ID"}}
 		for _, v := range versions {
-			table.Append([]string{v.Id, v.Name, fmt.Sprintf("%t", v.Archived), fmt.Sprintf("%t", v.Released), fmt.Sprintf("%d", v.ProjectId)})
+			table.Append(v.Id, v.Name, fmt.Sprintf("%t", v.Archived), fmt.Sprintf("%t", v.Released), fmt.Sprintf("%d", v.ProjectId))
 		}
-		table.Render() // Send output
+		cmdutil.Print(versions, table)
 	},
 }
 
 func init() {
 	Cmd.AddCommand(versionLsCmd)
+	versionLsCmd.Flags().IntP("limit", "l", 0, "Maximal number of listed versions, 0 means no limit")
 
 	// Here you will define your flags and configuration settings.
 
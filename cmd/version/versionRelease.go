@@ -17,6 +17,7 @@ package version
 
 import (
 	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/cmd/cmdutil"
 	"github.com/sotomskir/jira-cli/jiraApi"
 	"github.com/spf13/cobra"
 )
@@ -30,7 +31,9 @@ var versionReleaseCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		version := args[0]
 		projectKey := args[1]
-		jiraApi.ReleaseVersion(projectKey, version)
+		ctx, cancel := cmdutil.Context()
+		defer cancel()
+		cmdutil.CheckErr(jiraApi.ReleaseVersion(ctx, projectKey, version))
 		logrus.Infof("Success version %s from project %s released\n", version, projectKey)
 	},
 }
@@ -0,0 +1,61 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package credentials keeps secrets used to authenticate in JIRA outside of configuration file:
+// in Secret Service keyring, in passphrase encrypted file or in external credential helper
+package credentials
+
+import (
+	"errors"
+)
+
+// Store names accepted by configuration
+const (
+	StoreKeyring = "keyring"
+	StoreFile    = "file"
+	StoreHelper  = "helper"
+)
+
+// ErrNotFound is returned when store does not contain requested secret
+var ErrNotFound = errors.New("secret not found in credential store")
+
+// Key type identifies secret of JIRA server
+type Key struct {
+	// Server is JIRA server URL
+	Server string
+	// User is user name or e-mail, empty for tokens not bound to user
+	User string
+	// Kind is type of secret: password, token or access-token
+	Kind string
+}
+
+// username returns user of key, or kind of secret for tokens not bound to user
+func (k Key) username() string {
+	if k.User == "" {
+		return k.Kind
+	}
+	return k.User
+}
+
+// Store type keeps secrets
+type Store interface {
+	// Name returns name of store saved in configuration file
+	Name() string
+	// Get returns secret of key or ErrNotFound
+	Get(key Key) (string, error)
+	// Set saves secret of key
+	Set(key Key, secret string) error
+	// Delete removes secret of key
+	Delete(key Key) error
+}
@@ -0,0 +1,156 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"gotest.tools/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "jira-cli-credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// writeScript writes executable shell script to dir
+func writeScript(t *testing.T, dir string, name string, script string) string {
+	file := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(file, []byte("#!/bin/sh\n"+script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestEncryptedFile(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "credentials")
+	asked := make([]bool, 0)
+	passphrase := func(create bool) (string, error) {
+		asked = append(asked, create)
+		return "correct horse", nil
+	}
+	key := Key{Server: "https://jira.example.com", User: "user", Kind: "password"}
+	token := Key{Server: "https://jira2.example.com", Kind: "token"}
+
+	store := NewEncryptedFile(path, passphrase)
+	store.scryptN = 1 << 10
+	_, err := store.Get(key)
+	assert.Equal(t, err, ErrNotFound)
+	assert.NilError(t, store.Delete(key))
+	assert.DeepEqual(t, asked, []bool{})
+	_, err = os.Stat(path)
+	assert.Assert(t, os.IsNotExist(err), "credentials file created without secrets")
+	assert.NilError(t, store.Set(key, "secret"))
+	assert.NilError(t, store.Set(token, "pat"))
+	assert.NilError(t, store.Set(key, "changed"))
+	assert.DeepEqual(t, asked, []bool{true})
+
+	data, err := ioutil.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(data), "changed"), "credentials file contains plain text secret")
+	info, err := os.Stat(path)
+	assert.NilError(t, err)
+	assert.Equal(t, info.Mode().Perm(), os.FileMode(0600))
+
+	reopened := NewEncryptedFile(path, passphrase)
+	reopened.scryptN = 1 << 10
+	secret, err := reopened.Get(key)
+	assert.NilError(t, err)
+	assert.Equal(t, secret, "changed")
+	assert.NilError(t, reopened.Delete(token))
+	_, err = reopened.Get(token)
+	assert.Equal(t, err, ErrNotFound)
+	assert.DeepEqual(t, asked, []bool{true, false})
+
+	wrong := NewEncryptedFile(path, func(bool) (string, error) { return "wrong", nil })
+	wrong.scryptN = 1 << 10
+	_, err = wrong.Get(key)
+	assert.Equal(t, err, ErrWrongPassphrase)
+}
+
+func TestHelper(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	log := filepath.Join(dir, "log")
+	// helper saves its input and responds to get action with stored password
+	script := writeScript(t, dir, "helper", `
+cat >> `+log+`
+case "$2" in
+get) echo "password=pat" ;;
+esac
+`)
+	store := NewHelper(script + " --profile")
+	key := Key{Server: "https://jira.example.com/jira/", Kind: "token"}
+
+	assert.NilError(t, store.Set(key, "pat"))
+	secret, err := store.Get(key)
+	assert.NilError(t, err)
+	assert.Equal(t, secret, "pat")
+
+	data, err := ioutil.ReadFile(log)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "protocol=https\nhost=jira.example.com\npath=jira\nusername=token\npassword=pat\n\n"+
+		"protocol=https\nhost=jira.example.com\npath=jira\nusername=token\n\n")
+}
+
+func TestHelperNotFound(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	store := NewHelper(writeScript(t, dir, "helper", "cat > /dev/null\n"))
+
+	_, err := store.Get(Key{Server: "https://jira.example.com", User: "user", Kind: "password"})
+	assert.Equal(t, err, ErrNotFound)
+
+	failing := NewHelper(writeScript(t, dir, "failing", "echo locked >&2; exit 2\n"))
+	_, err = failing.Get(Key{Server: "https://jira.example.com", User: "user", Kind: "password"})
+	assert.ErrorContains(t, err, "credential helper get failed: exit status 2 locked")
+}
+
+func TestKeyring(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	log := filepath.Join(dir, "log")
+	// fake secret-tool prints secret for lookup of user attribute and exits with 1 for other users
+	script := writeScript(t, dir, "secret-tool", `
+echo "$@" >> `+log+`
+case "$1" in
+store) cat > /dev/null ;;
+lookup) [ "$7" = user ] && printf secret || exit 1 ;;
+esac
+`)
+	store := &Keyring{command: script}
+	key := Key{Server: "https://jira.example.com", User: "user", Kind: "password"}
+
+	assert.NilError(t, store.Set(key, "secret"))
+	secret, err := store.Get(key)
+	assert.NilError(t, err)
+	assert.Equal(t, secret, "secret")
+	_, err = store.Get(Key{Server: "https://jira.example.com", User: "other", Kind: "password"})
+	assert.Equal(t, err, ErrNotFound)
+
+	data, err := ioutil.ReadFile(log)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "store --label jira-cli password https://jira.example.com service jira-cli server https://jira.example.com user user kind password\n"+
+		"lookup service jira-cli server https://jira.example.com user user kind password\n"+
+		"lookup service jira-cli server https://jira.example.com user other kind password\n")
+}
@@ -0,0 +1,218 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// fileMagic starts every encrypted credentials file
+var fileMagic = []byte("jira-cli-credentials/v1\n")
+
+const saltSize = 16
+
+// ErrWrongPassphrase is returned when encrypted file can not be decrypted with passphrase
+var ErrWrongPassphrase = errors.New("cannot decrypt credentials file: wrong passphrase or corrupted file")
+
+// EncryptedFile type stores secrets in file encrypted with key derived from passphrase.
+// Key is derived with scrypt and secrets are sealed with XChaCha20-Poly1305
+type EncryptedFile struct {
+	path string
+	// passphrase returns passphrase of file. Create is true when file does not exist yet
+	passphrase func(create bool) (string, error)
+	// scryptN is scrypt cost parameter, lowered in tests
+	scryptN int
+	mu      sync.Mutex
+	key     []byte
+	salt    []byte
+}
+
+// fileEntry type represents single secret in encrypted file
+type fileEntry struct {
+	Key    Key    `json:"key"`
+	Secret string `json:"secret"`
+}
+
+// NewEncryptedFile method creates store in file at path. Passphrase is asked once, when store is used first time
+func NewEncryptedFile(path string, passphrase func(create bool) (string, error)) *EncryptedFile {
+	return &EncryptedFile{path: path, passphrase: passphrase, scryptN: 1 << 15}
+}
+
+// Name method returns StoreFile
+func (f *EncryptedFile) Name() string {
+	return StoreFile
+}
+
+// Get method returns secret of key
+func (f *EncryptedFile) Get(key Key) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entries, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if e.Key == key {
+			return e.Secret, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+// Set method saves secret of key
+func (f *EncryptedFile) Set(key Key, secret string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entries, err := f.read()
+	if err != nil {
+		return err
+	}
+	updated := []fileEntry{{Key: key, Secret: secret}}
+	for _, e := range entries {
+		if e.Key != key {
+			updated = append(updated, e)
+		}
+	}
+	return f.write(updated)
+}
+
+// Delete method removes secret of key
+func (f *EncryptedFile) Delete(key Key) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entries, err := f.read()
+	if err != nil {
+		return err
+	}
+	updated := make([]fileEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.Key != key {
+			updated = append(updated, e)
+		}
+	}
+	if len(updated) == len(entries) {
+		return nil
+	}
+	return f.write(updated)
+}
+
+// read decrypts entries of file. Missing file has no entries
+func (f *EncryptedFile) read() ([]fileEntry, error) {
+	data, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, fileMagic) || len(data) < len(fileMagic)+saltSize+chacha20poly1305.NonceSizeX {
+		return nil, errors.New("cannot read credentials file: unknown format")
+	}
+	data = data[len(fileMagic):]
+	salt, nonce, sealed := data[:saltSize], data[saltSize:saltSize+chacha20poly1305.NonceSizeX], data[saltSize+chacha20poly1305.NonceSizeX:]
+	if err := f.deriveKey(salt); err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(f.key)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := aead.Open(nil, nonce, sealed, fileMagic)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	entries := make([]fileEntry, 0)
+	return entries, json.Unmarshal(plain, &entries)
+}
+
+// write encrypts entries with fresh nonce and replaces file.
+// Passphrase of new file is asked here, so reading missing file does not ask for it
+func (f *EncryptedFile) write(entries []fileEntry) error {
+	if f.key == nil {
+		if err := f.deriveKey(nil); err != nil {
+			return err
+		}
+	}
+	plain, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	aead, err := chacha20poly1305.NewX(f.key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data := append(append(append([]byte{}, fileMagic...), f.salt...), nonce...)
+	data = aead.Seal(data, nonce, plain, fileMagic)
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return err
+	}
+	// temporary file is created with 0600 permissions
+	tmp, err := ioutil.TempFile(filepath.Dir(f.path), ".credentials-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// deriveKey derives key from passphrase and salt of file, nil salt means new file with random salt.
+// Key is derived once, as scrypt is slow by design
+func (f *EncryptedFile) deriveKey(salt []byte) error {
+	if f.key != nil && (salt == nil || bytes.Equal(salt, f.salt)) {
+		return nil
+	}
+	passphrase, err := f.passphrase(salt == nil)
+	if err != nil {
+		return err
+	}
+	if passphrase == "" {
+		return errors.New("passphrase of credentials file must not be empty")
+	}
+	if salt == nil {
+		salt = make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, f.scryptN, 8, 1, chacha20poly1305.KeySize)
+	if err != nil {
+		return err
+	}
+	f.key = key
+	f.salt = salt
+	return nil
+}
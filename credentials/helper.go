@@ -0,0 +1,106 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+// Helper type stores secrets with external command using git credential helper protocol.
+// Command is run with action argument: get, store or erase. Request attributes are written to its
+// standard input as key=value lines: protocol, host, path, username and password for store action.
+// Command of get action prints password=secret line. For tokens not bound to user, username is kind of secret.
+// Helpers of git like git-credential-libsecret or git-credential-osxkeychain can be used
+type Helper struct {
+	command []string
+}
+
+// NewHelper method creates store running given command line
+func NewHelper(command string) *Helper {
+	return &Helper{command: strings.Fields(command)}
+}
+
+// Name method returns StoreHelper
+func (h *Helper) Name() string {
+	return StoreHelper
+}
+
+// Get method returns secret of key
+func (h *Helper) Get(key Key) (string, error) {
+	out, err := h.run("get", key, "")
+	if err != nil {
+		return "", err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if kv := strings.SplitN(scanner.Text(), "=", 2); len(kv) == 2 && kv[0] == "password" {
+			return kv[1], nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+// Set method saves secret of key
+func (h *Helper) Set(key Key, secret string) error {
+	_, err := h.run("store", key, secret)
+	return err
+}
+
+// Delete method removes secret of key
+func (h *Helper) Delete(key Key) error {
+	_, err := h.run("erase", key, "")
+	return err
+}
+
+func (h *Helper) run(action string, key Key, secret string) ([]byte, error) {
+	if len(h.command) == 0 {
+		return nil, fmt.Errorf("credential helper command is not set")
+	}
+	input, err := helperInput(key, secret)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(h.command[0], append(h.command[1:], action)...)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("credential helper %s failed: %s %s", action, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// helperInput returns request attributes of key in git credential format
+func helperInput(key Key, secret string) (string, error) {
+	u, err := url.Parse(key.Server)
+	if err != nil {
+		return "", err
+	}
+	input := fmt.Sprintf("protocol=%s\nhost=%s\n", u.Scheme, u.Host)
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		input += fmt.Sprintf("path=%s\n", path)
+	}
+	input += fmt.Sprintf("username=%s\n", key.username())
+	if secret != "" {
+		input += fmt.Sprintf("password=%s\n", secret)
+	}
+	return input + "\n", nil
+}
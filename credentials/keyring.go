@@ -0,0 +1,92 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Keyring type stores secrets in Secret Service keyring (GNOME Keyring, KWallet) with secret-tool of libsecret
+type Keyring struct {
+	// command is name of secret-tool executable, replaced in tests
+	command string
+}
+
+// NewKeyring method creates keyring store
+func NewKeyring() *Keyring {
+	return &Keyring{command: "secret-tool"}
+}
+
+// Available method reports whether secret-tool is installed and D-Bus session is running
+func (k *Keyring) Available() bool {
+	if _, err := exec.LookPath(k.command); err != nil {
+		return false
+	}
+	return os.Getenv("DBUS_SESSION_BUS_ADDRESS") != ""
+}
+
+// Name method returns StoreKeyring
+func (k *Keyring) Name() string {
+	return StoreKeyring
+}
+
+// Get method returns secret of key
+func (k *Keyring) Get(key Key) (string, error) {
+	out, err := k.run(nil, "lookup", key)
+	if err != nil {
+		return "", err
+	}
+	if out == "" {
+		return "", ErrNotFound
+	}
+	return out, nil
+}
+
+// Set method saves secret of key
+func (k *Keyring) Set(key Key, secret string) error {
+	_, err := k.run([]byte(secret), "store", key)
+	return err
+}
+
+// Delete method removes secret of key
+func (k *Keyring) Delete(key Key) error {
+	_, err := k.run(nil, "clear", key)
+	return err
+}
+
+func (k *Keyring) run(stdin []byte, action string, key Key) (string, error) {
+	args := []string{action}
+	if action == "store" {
+		args = append(args, "--label", fmt.Sprintf("jira-cli %s %s", key.Kind, key.Server))
+	}
+	args = append(args, "service", "jira-cli", "server", key.Server, "user", key.username(), "kind", key.Kind)
+	cmd := exec.Command(k.command, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// lookup exits with status 1 and empty output when secret is not found
+		if action == "lookup" && stdout.Len() == 0 && stderr.Len() == 0 {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("keyring %s failed: %s %s", action, err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(stdout.String(), "\n"), nil
+}
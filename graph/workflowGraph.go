@@ -2,13 +2,18 @@ package graph
 
 import (
 	"container/list"
+	"errors"
 	"fmt"
-	"github.com/sirupsen/logrus"
 	"github.com/sotomskir/jira-cli/jiraApi/models"
-	"os"
 	"strings"
 )
 
+// ErrUnknownStatus is returned when workflow has no status with given name
+var ErrUnknownStatus = errors.New("unknown status")
+
+// ErrNoPath is returned when target status can't be reached from source status
+var ErrNoPath = errors.New("no path to status")
+
 type Graph struct {
 	VerticesCount uint
 	Vertices      map[string]*Vertex
@@ -47,6 +52,9 @@ func NewFromWorkflow(workflow *models.Workflow) *Graph {
 		graph.Transitions[status.Id] = make(map[string]*models.Transition)
 	}
 	for _, transition := range workflow.Layout.Transitions {
+		if graph.Vertices[transition.SourceId] == nil || graph.Vertices[transition.TargetId] == nil {
+			continue
+		}
 		t := transition
 		graph.Transitions[transition.SourceId][transition.TargetId] = &t
 		graph.AddEdge(transition.SourceId, transition.TargetId)
@@ -94,10 +102,18 @@ func (g Graph) BFS(fromId string) map[string]*Vertex {
 	return visited
 }
 
-func (g Graph) FindPath(fromId string, toId string) *list.List {
+func (g Graph) FindPath(fromId string, toId string) (*list.List, error) {
+	for _, id := range []string{fromId, toId} {
+		if g.Vertices[id] == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownStatus, id)
+		}
+	}
 	bfs := g.BFS(fromId)
-	path := list.New()
 	status := g.Vertices[toId].Status
+	if _, ok := bfs[toId]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoPath, status.Name)
+	}
+	path := list.New()
 	path.PushFront(PathNode{
 		Status:         &status,
 		NextTransition: nil,
@@ -115,16 +131,14 @@ func (g Graph) FindPath(fromId string, toId string) *list.List {
 		})
 	}
 
-	return path
+	return path, nil
 }
 
-func (g Graph) FindVertexByName(name string) *Vertex {
+func (g Graph) FindVertexByName(name string) (*Vertex, error) {
 	for _, v := range g.Vertices {
 		if strings.ToLower(strings.TrimSpace(v.Status.Name)) == strings.ToLower(strings.TrimSpace(name)) {
-			return v
+			return v, nil
 		}
 	}
-	logrus.Errorf(fmt.Sprintf("unknown status: %s", name))
-	os.Exit(1)
-	return nil
+	return nil, fmt.Errorf("%w: %s", ErrUnknownStatus, name)
 }
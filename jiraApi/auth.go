@@ -0,0 +1,147 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Authentication types supported by NewAuthenticator
+const (
+	// AuthBasic authenticates with user name and password
+	AuthBasic = "basic"
+	// AuthBearer authenticates with JIRA Data Center Personal Access Token
+	AuthBearer = "bearer"
+	// AuthCloudToken authenticates with JIRA Cloud account e-mail and API token
+	AuthCloudToken = "cloud-token"
+	// AuthOAuth1 authenticates with OAuth 1.0a access token of JIRA application link
+	AuthOAuth1 = "oauth1"
+)
+
+// AuthTypes is list of authentication types supported by NewAuthenticator
+var AuthTypes = []string{AuthBasic, AuthBearer, AuthCloudToken, AuthOAuth1}
+
+// Authenticator type adds credentials to every request sent to JIRA server
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthConfig type represents settings of authenticator created by NewAuthenticator
+type AuthConfig struct {
+	// Type is one of AuthTypes, empty means AuthBasic
+	Type string
+	// Username is user name for basic auth or account e-mail for JIRA Cloud API token
+	Username string
+	// Password is used by basic auth
+	Password string
+	// Token is Personal Access Token or JIRA Cloud API token
+	Token string
+	// ConsumerKey is consumer key of OAuth application link
+	ConsumerKey string
+	// PrivateKeyFile is PEM file with RSA key of OAuth application link
+	PrivateKeyFile string
+	// AccessToken is OAuth access token authorized by user
+	AccessToken string
+}
+
+// NewAuthenticator method creates authenticator of given type
+func NewAuthenticator(config AuthConfig) (Authenticator, error) {
+	switch strings.ToLower(config.Type) {
+	case AuthBasic, "":
+		return BasicAuth{Username: config.Username, Password: config.Password}, nil
+	case AuthBearer:
+		if config.Token == "" {
+			return nil, fmt.Errorf("%s authentication requires token", AuthBearer)
+		}
+		return BearerToken{Token: config.Token}, nil
+	case AuthCloudToken:
+		if config.Username == "" || config.Token == "" {
+			return nil, fmt.Errorf("%s authentication requires e-mail and API token", AuthCloudToken)
+		}
+		return CloudToken{Email: config.Username, Token: config.Token}, nil
+	case AuthOAuth1:
+		if config.AccessToken == "" {
+			return nil, fmt.Errorf("%s authentication requires access token, run login to authorize jira-cli", AuthOAuth1)
+		}
+		return NewOAuth1(config.ConsumerKey, config.PrivateKeyFile, config.AccessToken)
+	}
+	return nil, fmt.Errorf("unknown authentication type: %s, expected one of: %s", config.Type, strings.Join(AuthTypes, ", "))
+}
+
+// WithAuthenticator option sets authenticator of requests. Default is basic auth with username and password passed to NewClient
+func WithAuthenticator(auth Authenticator) Option {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// BasicAuth type authenticates requests with user name and password
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// Authenticate method sets basic auth header. Requests are not changed when both user name and password are empty
+func (a BasicAuth) Authenticate(req *http.Request) error {
+	if a.Username != "" || a.Password != "" {
+		req.SetBasicAuth(a.Username, a.Password)
+	}
+	return nil
+}
+
+// BearerToken type authenticates requests with JIRA Data Center Personal Access Token
+type BearerToken struct {
+	Token string
+}
+
+// Authenticate method sets bearer token header
+func (a BearerToken) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// CloudToken type authenticates requests to JIRA Cloud with account e-mail and API token
+type CloudToken struct {
+	Email string
+	Token string
+}
+
+// Authenticate method sets basic auth header with e-mail and API token
+func (a CloudToken) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.Email, a.Token)
+	return nil
+}
+
+// authTransport type is http.RoundTripper adding credentials to requests
+type authTransport struct {
+	next http.RoundTripper
+	auth Authenticator
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTripper must not modify request, so credentials are added to its copy
+	r := req.Clone(req.Context())
+	r.URL.User = nil
+	if err := t.auth.Authenticate(r); err != nil {
+		return nil, err
+	}
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return next.RoundTrip(r)
+}
@@ -0,0 +1,200 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// authorizationResponder responds with issue and saves Authorization header of request
+func authorizationResponder(header *string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		*header = req.Header.Get("Authorization")
+		return httpmock.NewStringResponse(200, readResponse("./responses/issue/TEST-1.json")), nil
+	}
+}
+
+func TestAuthenticators(t *testing.T) {
+	tests := []struct {
+		config   AuthConfig
+		expected string
+	}{
+		{AuthConfig{Username: "user", Password: "pass"}, "Basic dXNlcjpwYXNz"},
+		{AuthConfig{Type: AuthBearer, Token: "pat"}, "Bearer pat"},
+		{AuthConfig{Type: AuthCloudToken, Username: "user@example.com", Token: "token"}, "Basic dXNlckBleGFtcGxlLmNvbTp0b2tlbg=="},
+	}
+	for _, test := range tests {
+		t.Run(test.config.Type, func(t *testing.T) {
+			defer httpmock.DeactivateAndReset()
+			httpmock.Activate()
+			header := ""
+			httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/TEST-1", authorizationResponder(&header))
+			auth, err := NewAuthenticator(test.config)
+			assert.NilError(t, err)
+			c := NewClient("https://jira.example.com", test.config.Username, test.config.Password, WithAuthenticator(auth))
+
+			_, err = c.GetIssue(context.Background(), "TEST-1")
+			assert.NilError(t, err)
+			assert.Equal(t, header, test.expected)
+		})
+	}
+}
+
+func TestNewAuthenticatorErrors(t *testing.T) {
+	_, err := NewAuthenticator(AuthConfig{Type: "kerberos"})
+	assert.Error(t, err, "unknown authentication type: kerberos, expected one of: basic, bearer, cloud-token, oauth1")
+	_, err = NewAuthenticator(AuthConfig{Type: AuthBearer})
+	assert.Error(t, err, "bearer authentication requires token")
+	_, err = NewAuthenticator(AuthConfig{Type: AuthCloudToken, Token: "token"})
+	assert.Error(t, err, "cloud-token authentication requires e-mail and API token")
+	_, err = NewAuthenticator(AuthConfig{Type: AuthOAuth1, ConsumerKey: "jira-cli", PrivateKeyFile: "key.pem"})
+	assert.ErrorContains(t, err, "requires access token")
+	_, err = NewAuthenticator(AuthConfig{Type: AuthOAuth1, AccessToken: "token"})
+	assert.Error(t, err, "oauth1 authentication requires consumer key and private key file")
+}
+
+// writeRSAKey generates RSA key and writes it to PKCS #8 PEM file in dir
+func writeRSAKey(t *testing.T, dir string) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "oauth.pem")
+	if err := ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return key, file
+}
+
+// parseOAuthHeader returns parameters of OAuth authorization header
+func parseOAuthHeader(t *testing.T, header string) map[string]string {
+	assert.Assert(t, strings.HasPrefix(header, "OAuth "), "unexpected Authorization header: %s", header)
+	params := make(map[string]string)
+	for _, param := range strings.Split(strings.TrimPrefix(header, "OAuth "), ", ") {
+		kv := strings.SplitN(param, "=", 2)
+		value, err := url.PathUnescape(strings.Trim(kv[1], `"`))
+		assert.NilError(t, err)
+		params[kv[0]] = value
+	}
+	return params
+}
+
+// verifyOAuthSignature checks signature of request with public key
+func verifyOAuthSignature(t *testing.T, req *http.Request, key *rsa.PublicKey) map[string]string {
+	params := parseOAuthHeader(t, req.Header.Get("Authorization"))
+	signature, err := base64.StdEncoding.DecodeString(params["oauth_signature"])
+	assert.NilError(t, err)
+	oauth := make(map[string]string)
+	for k, v := range params {
+		if k != "oauth_signature" {
+			oauth[k] = v
+		}
+	}
+	hash := sha1.Sum([]byte(signatureBase(req, oauth)))
+	assert.NilError(t, rsa.VerifyPKCS1v15(key, crypto.SHA1, hash[:], signature))
+	return params
+}
+
+func TestOAuth1(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jira-cli-oauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	key, file := writeRSAKey(t, dir)
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	var params map[string]string
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/search",
+		func(req *http.Request) (*http.Response, error) {
+			params = verifyOAuthSignature(t, req, &key.PublicKey)
+			return httpmock.NewStringResponse(200, `{"startAt":0,"total":0,"issues":[]}`), nil
+		})
+	auth, err := NewAuthenticator(AuthConfig{Type: AuthOAuth1, ConsumerKey: "jira-cli", PrivateKeyFile: file, AccessToken: "access"})
+	assert.NilError(t, err)
+	c := NewClient("https://jira.example.com", "", "", WithAuthenticator(auth))
+
+	_, err = c.SearchIssues(context.Background(), "project = TEST AND summary ~ \"a+b\"", nil, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, params["oauth_consumer_key"], "jira-cli")
+	assert.Equal(t, params["oauth_token"], "access")
+	assert.Equal(t, params["oauth_signature_method"], "RSA-SHA1")
+}
+
+func TestOAuth1Dance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jira-cli-oauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	key, file := writeRSAKey(t, dir)
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	httpmock.RegisterResponder("POST", "https://jira.example.com/plugins/servlet/oauth/request-token",
+		func(req *http.Request) (*http.Response, error) {
+			params := verifyOAuthSignature(t, req, &key.PublicKey)
+			assert.Equal(t, params["oauth_callback"], "oob")
+			_, ok := params["oauth_token"]
+			assert.Assert(t, !ok, "request token request contains token")
+			return httpmock.NewStringResponse(200, "oauth_token=request&oauth_token_secret=secret"), nil
+		})
+	httpmock.RegisterResponder("POST", "https://jira.example.com/plugins/servlet/oauth/access-token",
+		func(req *http.Request) (*http.Response, error) {
+			params := verifyOAuthSignature(t, req, &key.PublicKey)
+			assert.Equal(t, params["oauth_token"], "request")
+			assert.Equal(t, params["oauth_verifier"], "123456")
+			return httpmock.NewStringResponse(200, "oauth_token=access&oauth_token_secret=secret"), nil
+		})
+	oauth, err := NewOAuth1("jira-cli", file, "")
+	assert.NilError(t, err)
+
+	token, authorizeUrl, err := oauth.RequestToken(context.Background(), http.DefaultClient, "https://jira.example.com")
+	assert.NilError(t, err)
+	assert.Equal(t, token, "request")
+	assert.Equal(t, authorizeUrl, "https://jira.example.com/plugins/servlet/oauth/authorize?oauth_token=request")
+	access, err := oauth.AccessToken(context.Background(), http.DefaultClient, "https://jira.example.com", token, "123456")
+	assert.NilError(t, err)
+	assert.Equal(t, access, "access")
+}
+
+func TestSignatureBase(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "HTTPS://Jira.Example.com:443/rest/api/2/search?jql=a%20b&b=2&a=1", nil)
+	assert.NilError(t, err)
+
+	base := signatureBase(req, map[string]string{"oauth_token": "t~k", "a-b": "3"})
+
+	assert.Equal(t, base, "GET&https%3A%2F%2Fjira.example.com%2Frest%2Fapi%2F2%2Fsearch&"+
+		"a%3D1%26a-b%3D3%26b%3D2%26jql%3Da%2520b%26oauth_token%3Dt~k")
+}
@@ -0,0 +1,125 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"errors"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/resty.v1"
+	"net/http"
+)
+
+// BulkCreateSize is maximal number of issues created with single bulk request
+var BulkCreateSize = 50
+
+// CreateIssues method creates issues with bulk requests. Returned issues are in order of given fields,
+// failures are returned by position of issue. When bulk request is rejected as whole, e.g. because every issue
+// is invalid or endpoint is missing, issues of request are created one by one to report error of each
+func (c *Client) CreateIssues(ctx context.Context, fieldsList []models.Fields) ([]models.Issue, map[int]error) {
+	issues := make([]models.Issue, len(fieldsList))
+	failures := make(map[int]error)
+	for start := 0; start < len(fieldsList); start += BulkCreateSize {
+		end := start + BulkCreateSize
+		if end > len(fieldsList) {
+			end = len(fieldsList)
+		}
+		created, chunkFailures := c.createIssuesChunk(ctx, fieldsList[start:end])
+		for i, issue := range created {
+			issues[start+i] = issue
+		}
+		for i, err := range chunkFailures {
+			failures[start+i] = err
+		}
+	}
+	return issues, failures
+}
+
+// createIssuesChunk creates issues with single bulk request. Issues which can't be prepared are reported as failed
+// and the rest is still sent, positions of sent issues map elements of bulk request to given fields
+func (c *Client) createIssuesChunk(ctx context.Context, fieldsList []models.Fields) ([]models.Issue, map[int]error) {
+	issues := make([]models.Issue, len(fieldsList))
+	failures := make(map[int]error)
+	request := models.IssueBulkRequest{IssueUpdates: make([]models.Issue, 0, len(fieldsList))}
+	sent := make([]int, 0, len(fieldsList))
+	for i, fields := range fieldsList {
+		fields, err := c.createFields(ctx, fields)
+		if err != nil {
+			failures[i] = err
+			continue
+		}
+		request.IssueUpdates = append(request.IssueUpdates, models.Issue{Fields: fields})
+		sent = append(sent, i)
+	}
+	if len(sent) == 0 {
+		return issues, failures
+	}
+	response := models.IssueBulkResponse{}
+	_, err := c.execute(ctx, resty.MethodPost, "rest/api/2/issue/bulk", request, &response, "", nil)
+	var apiError *APIError
+	if errors.As(err, &apiError) && (apiError.StatusCode == http.StatusBadRequest || apiError.StatusCode == http.StatusNotFound) {
+		for _, i := range sent {
+			issue, err := c.CreateIssueWithFields(ctx, fieldsList[i])
+			if err != nil {
+				failures[i] = err
+			}
+			issues[i] = issue
+		}
+		return issues, failures
+	}
+	if err != nil {
+		for _, i := range sent {
+			failures[i] = err
+		}
+		return issues, failures
+	}
+	for _, e := range response.Errors {
+		if e.FailedElementNumber < 0 || e.FailedElementNumber >= len(sent) {
+			continue
+		}
+		failures[sent[e.FailedElementNumber]] = &APIError{
+			StatusCode:    e.Status,
+			Method:        resty.MethodPost,
+			Endpoint:      "rest/api/2/issue/bulk",
+			ErrorMessages: e.ElementErrors.ErrorMessages,
+			Errors:        e.ElementErrors.Errors,
+		}
+	}
+	created := response.Issues
+	for _, i := range sent {
+		if _, failed := failures[i]; failed {
+			continue
+		}
+		if len(created) == 0 {
+			failures[i] = errors.New("issue is missing from bulk create response")
+			continue
+		}
+		issues[i] = created[0]
+		created = created[1:]
+	}
+	return issues, failures
+}
+
+// LinkIssues method links issues with link of given type name, where outward issue relates to inward issue
+// with outward description of type, e.g. outward issue blocks inward issue
+func (c *Client) LinkIssues(ctx context.Context, linkType string, outwardKey string, inwardKey string) error {
+	link := models.IssueLink{
+		Type:         models.IssueLinkType{Name: linkType},
+		InwardIssue:  models.IssueRef{Key: inwardKey},
+		OutwardIssue: models.IssueRef{Key: outwardKey},
+	}
+	_, err := c.execute(ctx, resty.MethodPost, "rest/api/2/issueLink", link, nil, "", nil)
+	return err
+}
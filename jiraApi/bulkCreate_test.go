@@ -0,0 +1,142 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestCreateIssues(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	requests := make([]models.IssueBulkRequest, 0)
+	httpmock.RegisterResponder("POST", "https://jira.example.com/rest/api/2/issue/bulk",
+		func(req *http.Request) (*http.Response, error) {
+			request := models.IssueBulkRequest{}
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &request); err != nil {
+				return nil, err
+			}
+			requests = append(requests, request)
+			return httpmock.NewStringResponse(201, readResponse("./responses/issue/bulk/partial.json")), nil
+		})
+
+	fieldsList := []models.Fields{
+		{Summary: "First", Project: &models.Project{Key: "TEST"}, IssueType: &models.IssueType{Name: "Task"}},
+		{Summary: "Second", Project: &models.Project{Key: "TEST"}, IssueType: &models.IssueType{Name: "Unknown"}},
+		{Summary: "Third", Project: &models.Project{Key: "TEST"}, IssueType: &models.IssueType{Name: "Task"}},
+	}
+	issues, failures := c.CreateIssues(context.Background(), fieldsList)
+	assert.Equal(t, len(requests), 1)
+	assert.Equal(t, len(requests[0].IssueUpdates), 3)
+	assert.Equal(t, issues[0].Key, "TEST-10")
+	assert.Equal(t, issues[1].Key, "")
+	assert.Equal(t, issues[2].Key, "TEST-11")
+	assert.Equal(t, len(failures), 1)
+	var apiError *APIError
+	assert.Assert(t, errors.As(failures[1], &apiError))
+	assert.Equal(t, apiError.Errors["issuetype"], "valid issue type is required")
+}
+
+func TestCreateIssuesInvalidFields(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	requests := make([]models.IssueBulkRequest, 0)
+	httpmock.RegisterResponder("POST", "https://jira.example.com/rest/api/2/issue/bulk",
+		func(req *http.Request) (*http.Response, error) {
+			request := models.IssueBulkRequest{}
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &request); err != nil {
+				return nil, err
+			}
+			requests = append(requests, request)
+			return httpmock.NewStringResponse(201, readResponse("./responses/issue/bulk/partial.json")), nil
+		})
+
+	invalid := models.Fields{Summary: "Invalid", Extra: map[string]json.RawMessage{"customfield_10002": json.RawMessage("{")}}
+	fieldsList := []models.Fields{
+		{Summary: "First", Project: &models.Project{Key: "TEST"}, IssueType: &models.IssueType{Name: "Task"}},
+		invalid,
+		{Summary: "Second", Project: &models.Project{Key: "TEST"}, IssueType: &models.IssueType{Name: "Unknown"}},
+		{Summary: "Third", Project: &models.Project{Key: "TEST"}, IssueType: &models.IssueType{Name: "Task"}},
+	}
+	issues, failures := c.CreateIssues(context.Background(), fieldsList)
+	assert.Equal(t, len(requests), 1)
+	assert.Equal(t, len(requests[0].IssueUpdates), 3)
+	assert.Equal(t, issues[0].Key, "TEST-10")
+	assert.Equal(t, issues[1].Key, "")
+	assert.Equal(t, issues[2].Key, "")
+	assert.Equal(t, issues[3].Key, "TEST-11")
+	assert.Equal(t, len(failures), 2)
+	assert.Assert(t, failures[1] != nil)
+	var apiError *APIError
+	assert.Assert(t, errors.As(failures[2], &apiError))
+	assert.Equal(t, apiError.Errors["issuetype"], "valid issue type is required")
+}
+
+func TestCreateIssuesFallback(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	httpmock.RegisterResponder("POST", "https://jira.example.com/rest/api/2/issue/bulk",
+		httpmock.NewStringResponder(400, `{"issues":[],"errors":[]}`))
+	httpmock.RegisterResponder("POST", "https://jira.example.com/rest/api/2/issue",
+		func(req *http.Request) (*http.Response, error) {
+			issue := models.Issue{}
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &issue); err != nil {
+				return nil, err
+			}
+			if issue.Fields.Summary == "" {
+				return httpmock.NewStringResponse(400, `{"errorMessages":[],"errors":{"summary":"You must specify a summary of the issue."}}`), nil
+			}
+			return httpmock.NewStringResponse(201, `{"id":"10010","key":"TEST-10","self":"https://jira.example.com/rest/api/2/issue/10010"}`), nil
+		})
+
+	issues, failures := c.CreateIssues(context.Background(), []models.Fields{{Summary: "First"}, {}})
+	assert.Equal(t, issues[0].Key, "TEST-10")
+	assert.Equal(t, len(failures), 1)
+	assert.Error(t, failures[1], "http error: 400: summary: You must specify a summary of the issue.")
+}
+
+func TestLinkIssues(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	link := models.IssueLink{}
+	httpmock.RegisterResponder("POST", "https://jira.example.com/rest/api/2/issueLink",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &link); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(201, ""), nil
+		})
+
+	assert.NilError(t, c.LinkIssues(context.Background(), "Blocks", "TEST-1", "TEST-2"))
+	assert.Equal(t, link.Type.Name, "Blocks")
+	assert.Equal(t, link.OutwardIssue.Key, "TEST-1")
+	assert.Equal(t, link.InwardIssue.Key, "TEST-2")
+}
@@ -0,0 +1,233 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Resource types stored in Cache
+const (
+	CacheProjects  = "projects"
+	CacheVersions  = "versions"
+	CacheWorkflows = "workflows"
+	CacheFields    = "fields"
+)
+
+// DefaultCacheTTL defines how long cached responses of every resource type are used without asking server
+var DefaultCacheTTL = map[string]time.Duration{
+	CacheProjects:  24 * time.Hour,
+	CacheVersions:  1 * time.Hour,
+	CacheWorkflows: 24 * time.Hour,
+	CacheFields:    24 * time.Hour,
+}
+
+// cacheKinds maps GET endpoints to cached resource types
+var cacheKinds = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{CacheProjects, regexp.MustCompile(`^rest/api/[23]/project(/search|/[^/]+)?$`)},
+	{CacheVersions, regexp.MustCompile(`^rest/api/[23]/project/[^/]+/version$`)},
+	{CacheWorkflows, regexp.MustCompile(`^rest/workflowDesigner/latest/workflows$`)},
+	{CacheWorkflows, regexp.MustCompile(`^rest/api/[23]/workflowscheme/project$`)},
+	{CacheWorkflows, regexp.MustCompile(`^rest/api/[23]/workflow/search$`)},
+	{CacheWorkflows, regexp.MustCompile(`^rest/projectconfig/latest/workflowscheme/[^/]+$`)},
+	{CacheFields, regexp.MustCompile(`^rest/api/[23]/field$`)},
+}
+
+// Cache type stores responses of rarely changing resources on disk.
+// Expired entries with ETag are revalidated with If-None-Match request header
+type Cache struct {
+	dir string
+	ttl map[string]time.Duration
+	now func() time.Time
+}
+
+// cacheEntry type represents single cached response
+type cacheEntry struct {
+	ETag   string          `json:"etag,omitempty"`
+	Stored time.Time       `json:"stored"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// NewCache method creates cache stored in given directory.
+// TTL of resource types missing in ttl map is taken from DefaultCacheTTL
+func NewCache(dir string, ttl map[string]time.Duration) *Cache {
+	cache := &Cache{
+		dir: dir,
+		ttl: make(map[string]time.Duration),
+		now: time.Now,
+	}
+	for kind, d := range DefaultCacheTTL {
+		cache.ttl[kind] = d
+	}
+	for kind, d := range ttl {
+		cache.ttl[kind] = d
+	}
+	return cache
+}
+
+// DefaultCacheDir method returns jira-cli directory in user cache directory
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "jira-cli"), nil
+}
+
+// WithCache option enables response cache. Cache is disabled by default
+func WithCache(cache *Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// Clear method removes all cached responses
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+// endpointCacheKind returns resource type of endpoint or empty string when endpoint is not cached
+func endpointCacheKind(endpoint string) string {
+	path := strings.SplitN(endpoint, "?", 2)[0]
+	for _, k := range cacheKinds {
+		if k.pattern.MatchString(path) {
+			return k.kind
+		}
+	}
+	return ""
+}
+
+func hash(values ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(values, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// prefix returns file name prefix shared by cached responses of endpoint, so they can be invalidated together
+func (c *Cache) prefix(client *Client, endpoint string) string {
+	return hash(client.serverUrl, client.username, strings.SplitN(endpoint, "?", 2)[0])
+}
+
+// path returns file of cached response
+func (c *Cache) path(client *Client, kind string, endpoint string, queryString string) string {
+	if parts := strings.SplitN(endpoint, "?", 2); len(parts) > 1 {
+		queryString = parts[1] + "&" + queryString
+	}
+	return filepath.Join(c.dir, kind, c.prefix(client, endpoint)+"-"+hash(queryString)+".json")
+}
+
+// get returns cached entry and true when entry is still fresh
+func (c *Cache) get(file string, kind string) (*cacheEntry, bool) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, false
+	}
+	entry := cacheEntry{}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, c.now().Sub(entry.Stored) < c.ttl[kind]
+}
+
+// put stores entry. File is replaced atomically, so concurrent readers never see partial entry
+func (c *Cache) put(file string, entry cacheEntry) error {
+	entry.Stored = c.now()
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(file), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// invalidate removes all cached responses of endpoint
+func (c *Cache) invalidate(client *Client, kind string, endpoint string) {
+	files, _ := filepath.Glob(filepath.Join(c.dir, kind, c.prefix(client, endpoint)+"-*"))
+	for _, file := range files {
+		os.Remove(file)
+	}
+}
+
+// cacheKind returns resource type of endpoint when cache is enabled
+func (c *Client) cacheKind(endpoint string) string {
+	if c.cache == nil {
+		return ""
+	}
+	return endpointCacheKind(endpoint)
+}
+
+// storeResponse stores response in cache file, failure only disables caching of this response
+func (c *Client) storeResponse(file string, entry cacheEntry) {
+	if err := c.cache.put(file, entry); err != nil {
+		logrus.Debugf("Cannot write cache: %s\n", err)
+	}
+}
+
+// loadCached decodes fresh cached value of endpoint into v. It returns false when cache is disabled or value is missing
+func (c *Client) loadCached(kind string, endpoint string, v interface{}) bool {
+	if c.cache == nil {
+		return false
+	}
+	entry, fresh := c.cache.get(c.cache.path(c, kind, endpoint, ""), kind)
+	return fresh && json.Unmarshal(entry.Body, v) == nil
+}
+
+// storeCached stores value of endpoint when cache is enabled
+func (c *Client) storeCached(kind string, endpoint string, v interface{}) {
+	if c.cache == nil {
+		return
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	c.storeResponse(c.cache.path(c, kind, endpoint, ""), cacheEntry{Body: body})
+}
+
+// invalidateCached removes cached responses of endpoint when cache is enabled
+func (c *Client) invalidateCached(kind string, endpoint string) {
+	if c.cache != nil {
+		c.flavorMu.Lock()
+		flavor := c.flavor
+		c.flavorMu.Unlock()
+		c.cache.invalidate(c, kind, apiEndpoint(flavor, endpoint))
+	}
+}
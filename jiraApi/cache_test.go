@@ -0,0 +1,183 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func newCacheTestClient(t *testing.T) (*Client, *Cache, func()) {
+	dir, err := ioutil.TempDir("", "jira-cli-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := NewCache(dir, map[string]time.Duration{CacheProjects: time.Minute})
+	c := NewClient("https://jira.example.com", "user", "pass", WithCache(cache))
+	return c, cache, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestCacheFreshEntry(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c, _, cleanup := newCacheTestClient(t)
+	defer cleanup()
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/project/TEST",
+		httpmock.NewStringResponder(200, readResponse("./responses/project/TEST.json")))
+
+	first, err := c.GetProject(context.Background(), "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.GetProject(context.Background(), "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.DeepEqual(t, first, second)
+	assert.Equal(t, httpmock.GetTotalCallCount(), 1)
+
+	other := NewClient("https://jira.example.com", "other", "pass", WithCache(c.cache))
+	_, err = other.GetProject(context.Background(), "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, httpmock.GetTotalCallCount(), 2)
+}
+
+func TestCacheExpiredEntry(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c, cache, cleanup := newCacheTestClient(t)
+	defer cleanup()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/project/TEST",
+		httpmock.NewStringResponder(200, readResponse("./responses/project/TEST.json")))
+
+	c.GetProject(context.Background(), "TEST")
+	now = now.Add(2 * time.Minute)
+	c.GetProject(context.Background(), "TEST")
+	assert.Equal(t, httpmock.GetTotalCallCount(), 2)
+}
+
+func TestCacheETagRevalidation(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c, cache, cleanup := newCacheTestClient(t)
+	defer cleanup()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	response := readResponse("./responses/project/TEST.json")
+	ifNoneMatch := make([]string, 0)
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/project/TEST",
+		func(req *http.Request) (*http.Response, error) {
+			ifNoneMatch = append(ifNoneMatch, req.Header.Get("If-None-Match"))
+			if req.Header.Get("If-None-Match") == `"v1"` {
+				return httpmock.NewStringResponse(304, ""), nil
+			}
+			res := httpmock.NewStringResponse(200, response)
+			res.Header.Set("ETag", `"v1"`)
+			return res, nil
+		})
+
+	first, _ := c.GetProject(context.Background(), "TEST")
+	now = now.Add(2 * time.Minute)
+	second, err := c.GetProject(context.Background(), "TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	third, _ := c.GetProject(context.Background(), "TEST")
+	assert.DeepEqual(t, ifNoneMatch, []string{"", `"v1"`})
+	assert.DeepEqual(t, first, second)
+	assert.DeepEqual(t, first, third)
+}
+
+func TestCacheInvalidatedByUpdate(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c, _, cleanup := newCacheTestClient(t)
+	defer cleanup()
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/project/TEST",
+		httpmock.NewStringResponder(200, readResponse("./responses/project/TEST.json")))
+	httpmock.RegisterResponder("PUT", "https://jira.example.com/rest/api/2/project/TEST",
+		httpmock.NewStringResponder(204, ""))
+
+	c.GetProject(context.Background(), "TEST")
+	c.execute(context.Background(), "PUT", "rest/api/2/project/TEST", models.Project{Key: "TEST"}, nil, "", nil)
+	c.GetProject(context.Background(), "TEST")
+	assert.Equal(t, httpmock.GetTotalCallCount(), 3)
+}
+
+func TestCacheCloud(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	dir, err := ioutil.TempDir("", "jira-cli-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	c := NewClient("https://jira.example.com", "user", "pass", WithCache(NewCache(dir, nil)), WithFlavor(FlavorCloud))
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/3/project/TEST",
+		httpmock.NewStringResponder(200, readResponse("./responses/project/TEST.json")))
+	httpmock.RegisterResponder("PUT", "https://jira.example.com/rest/api/3/project/TEST",
+		httpmock.NewStringResponder(204, ""))
+
+	c.GetProject(context.Background(), "TEST")
+	c.GetProject(context.Background(), "TEST")
+	assert.Equal(t, httpmock.GetTotalCallCount(), 1)
+	c.execute(context.Background(), "PUT", "rest/api/2/project/TEST", models.Project{Key: "TEST"}, nil, "", nil)
+	c.GetProject(context.Background(), "TEST")
+	assert.Equal(t, httpmock.GetTotalCallCount(), 3)
+}
+
+func TestCacheClear(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c, cache, cleanup := newCacheTestClient(t)
+	defer cleanup()
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/project/TEST",
+		httpmock.NewStringResponder(200, readResponse("./responses/project/TEST.json")))
+
+	c.GetProject(context.Background(), "TEST")
+	assert.NilError(t, cache.Clear())
+	c.GetProject(context.Background(), "TEST")
+	assert.Equal(t, httpmock.GetTotalCallCount(), 2)
+}
+
+func TestEndpointCacheKind(t *testing.T) {
+	assert.Equal(t, endpointCacheKind("rest/api/2/project/search"), CacheProjects)
+	assert.Equal(t, endpointCacheKind("rest/api/2/project/TEST/version"), CacheVersions)
+	assert.Equal(t, endpointCacheKind("rest/workflowDesigner/latest/workflows?name=Test"), CacheWorkflows)
+	assert.Equal(t, endpointCacheKind("rest/api/2/workflowscheme/project?projectId=10001"), CacheWorkflows)
+	assert.Equal(t, endpointCacheKind("rest/projectconfig/latest/workflowscheme/TEST"), CacheWorkflows)
+	assert.Equal(t, endpointCacheKind("rest/api/2/project/TEST"), CacheProjects)
+	assert.Equal(t, endpointCacheKind("rest/api/2/issue/TEST-1/transitions"), "")
+	assert.Equal(t, endpointCacheKind("rest/api/2/issue/TEST-1"), "")
+	assert.Equal(t, endpointCacheKind("rest/api/3/project/TEST/version"), CacheVersions)
+	assert.Equal(t, endpointCacheKind("rest/api/3/workflowscheme/project?projectId=10001"), CacheWorkflows)
+	assert.Equal(t, endpointCacheKind("rest/api/3/project/TEST"), CacheProjects)
+	assert.Equal(t, endpointCacheKind("rest/api/3/issue/TEST-1/transitions"), "")
+	assert.Equal(t, endpointCacheKind("rest/api/3/field"), CacheFields)
+	assert.Equal(t, endpointCacheKind("rest/api/3/issue/TEST-1"), "")
+}
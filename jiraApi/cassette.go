@@ -0,0 +1,254 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// redactedHeaders are replaced with "REDACTED" before interaction is saved to cassette
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Cassette type represents HTTP interactions saved in record mode and served in replay mode
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction type represents single recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest type represents request saved in cassette. URL has no credentials
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse type represents response saved in cassette
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// LoadCassette method reads cassette file saved in record mode
+func LoadCassette(file string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	cassette := Cassette{}
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", file, err)
+	}
+	return &cassette, nil
+}
+
+// Save method writes cassette to file
+func (c *Cassette) Save(file string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(file), ".cassette-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// WithRecorder option saves every request sent by Client and its response to cassette file.
+// Credentials are redacted
+func WithRecorder(file string) Option {
+	return func(c *Client) {
+		c.wrapTransport = func(next http.RoundTripper) http.RoundTripper {
+			return &recorder{next: next, file: file, cassette: &Cassette{Interactions: make([]Interaction, 0)}}
+		}
+	}
+}
+
+// WithReplay option makes Client respond with interactions from cassette. No request is sent to server
+func WithReplay(cassette *Cassette) Option {
+	return func(c *Client) {
+		c.wrapTransport = func(next http.RoundTripper) http.RoundTripper {
+			return &replayer{cassette: cassette, used: make([]bool, len(cassette.Interactions))}
+		}
+	}
+}
+
+// recordedURL returns request URL without credentials
+func recordedURL(u *url.URL) string {
+	recorded := *u
+	recorded.User = nil
+	return recorded.String()
+}
+
+// redact returns copy of headers with credentials replaced
+func redact(headers http.Header) http.Header {
+	redacted := http.Header{}
+	for k, v := range headers {
+		redacted[k] = v
+	}
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "REDACTED")
+		}
+	}
+	return redacted
+}
+
+// readBody reads body and returns reader which can be read again
+func readBody(body io.ReadCloser) ([]byte, io.ReadCloser, error) {
+	if body == nil {
+		return nil, nil, nil
+	}
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+	return data, ioutil.NopCloser(bytes.NewReader(data)), err
+}
+
+// recorder type is http.RoundTripper saving interactions to cassette file
+type recorder struct {
+	next     http.RoundTripper
+	file     string
+	mu       sync.Mutex
+	cassette *Cassette
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, body, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = body
+	next := r.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	res, err := next.RoundTrip(req)
+	if err != nil {
+		return res, err
+	}
+	resBody, body, err := readBody(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	res.Body = body
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     recordedURL(req.URL),
+			Headers: redact(req.Header),
+			Body:    string(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Headers:    redact(res.Header),
+			Body:       string(resBody),
+		},
+	})
+	// cassette is saved after every interaction, so it is complete even when command is interrupted
+	if err := r.cassette.Save(r.file); err != nil {
+		logrus.Warnf("Cannot save cassette %s: %s\n", r.file, err)
+	}
+	return res, nil
+}
+
+// replayer type is http.RoundTripper responding with interactions from cassette.
+// Interactions are matched by method, path and query in recorded order.
+// When all matching interactions were used, the last one is repeated
+type replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !sameRequest(interaction.Request, req) {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, errors.New(fmt.Sprintf("replay: no recorded response for %s %s", req.Method, req.URL.RequestURI()))
+	}
+	r.used[match] = true
+	recorded := r.cassette.Interactions[match].Response
+	headers := http.Header{}
+	for k, v := range recorded.Headers {
+		headers[k] = v
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          ioutil.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// sameRequest returns true when request has method, path and query of recorded request
+func sameRequest(recorded RecordedRequest, req *http.Request) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+	u, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	return u.Path == req.URL.Path && u.Query().Encode() == req.URL.Query().Encode()
+}
@@ -0,0 +1,94 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jira-cli-cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "cassette.json")
+
+	httpmock.Activate()
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/TEST-1",
+		httpmock.NewStringResponder(200, readResponse("./responses/issue/TEST-1.json")))
+	registerWorkflowScheme()
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/workflowDesigner/latest/workflows?name=test-workflow",
+		httpmock.NewStringResponder(200, readResponse("./responses/workflows/workflow.json")))
+	recording := NewClient("https://jira.example.com", "user", "secret", WithRecorder(file))
+	recordedIssue, err := recording.GetIssue(context.Background(), "TEST-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordedWorkflow, err := recording.GetIssueWorkflow(context.Background(), "TEST-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpmock.DeactivateAndReset()
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Assert(t, !strings.Contains(string(data), "secret"), "cassette contains password")
+	assert.Assert(t, !strings.Contains(string(data), "Basic "), "cassette contains Authorization header")
+
+	cassette, err := LoadCassette(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(cassette.Interactions), 5)
+	replaying := NewClient("https://jira.example.com", "user", "secret", WithReplay(cassette))
+	issue, err := replaying.GetIssue(context.Background(), "TEST-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	workflow, err := replaying.GetIssueWorkflow(context.Background(), "TEST-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.DeepEqual(t, issue, recordedIssue)
+	assert.DeepEqual(t, workflow, recordedWorkflow)
+}
+
+func TestReplayMissingInteraction(t *testing.T) {
+	cassette := &Cassette{Interactions: []Interaction{{
+		Request:  RecordedRequest{Method: "GET", URL: "https://jira.example.com/rest/api/2/issue/TEST-1"},
+		Response: RecordedResponse{StatusCode: 200, Body: readResponse("./responses/issue/TEST-1.json")},
+	}}}
+	c := NewClient("https://jira.example.com", "user", "pass", WithReplay(cassette))
+
+	_, err := c.GetIssue(context.Background(), "TEST-2")
+	assert.ErrorContains(t, err, "replay: no recorded response for GET /rest/api/2/issue/TEST-2")
+	issue, err := c.GetIssue(context.Background(), "TEST-1")
+	assert.NilError(t, err)
+	assert.Equal(t, issue.Key, "TEST-1")
+	// last matching interaction is repeated
+	issue, err = c.GetIssue(context.Background(), "TEST-1")
+	assert.NilError(t, err)
+	assert.Equal(t, issue.Key, "TEST-1")
+}
@@ -0,0 +1,263 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+	"time"
+)
+
+// Client type represents JIRA REST API client bound to single server.
+// Every client has its own HTTP client, base URL and credentials,
+// so many clients can talk to different servers at the same time.
+type Client struct {
+	rest        *resty.Client
+	httpClient  *http.Client
+	fetchClient *http.Client
+	transport   http.RoundTripper
+	serverUrl   string
+	username    string
+	password    string
+	auth        Authenticator
+	timeout     time.Duration
+	userAgent   string
+	retryPolicy RetryPolicy
+	concurrency int
+	slots       chan struct{}
+	limiter     *rateLimiter
+	cache       *Cache
+	flavor      Flavor
+	flavorMu    sync.Mutex
+	workflows   map[string]*workflowCall
+	workflowsMu sync.Mutex
+	fields      []models.Field
+	fieldsMu    sync.Mutex
+	// wrapTransport wraps transport of HTTP client, it is used by record and replay modes
+	wrapTransport func(http.RoundTripper) http.RoundTripper
+	sleep         func(context.Context, time.Duration) error
+}
+
+// Option type represents optional Client setting passed to NewClient
+type Option func(*Client)
+
+// WithTimeout option sets timeout of every HTTP request. Default is one minute
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithHTTPClient option makes Client send requests with given http.Client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserAgent option sets User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// NewClient method creates new API client for given server and credentials.
+// Username and password are used for basic auth unless other authenticator is set with WithAuthenticator
+func NewClient(serverUrl string, username string, password string, options ...Option) *Client {
+	c := &Client{
+		serverUrl:   serverUrl,
+		username:    username,
+		password:    password,
+		timeout:     1 * time.Minute,
+		userAgent:   "jira-cli",
+		retryPolicy: DefaultRetryPolicy,
+		flavor:      FlavorServer,
+		sleep:       sleepContext,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	if c.auth == nil {
+		c.auth = BasicAuth{Username: username, Password: password}
+	}
+	if c.concurrency > 0 {
+		c.slots = make(chan struct{}, c.concurrency)
+	}
+	if c.httpClient == nil {
+		jar, _ := cookiejar.New(nil)
+		c.httpClient = &http.Client{Jar: jar, Transport: c.transport}
+	}
+	if c.wrapTransport != nil {
+		httpClient := *c.httpClient
+		httpClient.Transport = c.wrapTransport(httpClient.Transport)
+		c.httpClient = &httpClient
+	}
+	// files from other hosts, e.g. workflow url, are fetched without credentials of JIRA server
+	c.fetchClient = c.httpClient
+	// credentials are added by transport, so they are sent also with requests not made by resty
+	httpClient := *c.httpClient
+	httpClient.Transport = &authTransport{next: httpClient.Transport, auth: c.auth}
+	c.httpClient = &httpClient
+	c.rest = resty.NewWithClient(c.httpClient)
+	c.rest.SetHostURL(serverUrl)
+	c.rest.SetTimeout(c.timeout)
+	// Headers for all request
+	c.rest.SetHeader("Accept", "application/json")
+	c.rest.SetHeaders(map[string]string{
+		"Content-Type":      "application/json",
+		"User-Agent":        c.userAgent,
+		"X-Atlassian-Token": "no-check",
+	})
+	return c
+}
+
+// ServerUrl method returns base URL of JIRA server used by client
+func (c *Client) ServerUrl() string {
+	return c.serverUrl
+}
+
+// Username method returns name of user used to authenticate requests
+func (c *Client) Username() string {
+	return c.username
+}
+
+func (c *Client) request(ctx context.Context, payload interface{}, queryString string, headers map[string]string) *resty.Request {
+	r := c.rest.R()
+	r.SetContext(ctx)
+	if payload != nil {
+		r.SetBody(payload)
+		p, _ := json.Marshal(payload)
+		logrus.Tracef("Request payload: %s\n", string(p))
+	}
+
+	if queryString != "" {
+		r.SetQueryString(queryString)
+	}
+
+	if headers != nil {
+		r.SetHeaders(headers)
+	}
+	return r
+}
+
+// execute sends request to endpoint of REST API version used by client flavor
+func (c *Client) execute(ctx context.Context, method string, endpoint string, payload interface{}, response interface{}, queryString string, headers map[string]string) (int, error) {
+	flavor, err := c.Flavor(ctx)
+	if err != nil {
+		return 1, err
+	}
+	return c.send(ctx, method, apiEndpoint(flavor, endpoint), payload, response, queryString, headers)
+}
+
+func (c *Client) send(ctx context.Context, method string, endpoint string, payload interface{}, response interface{}, queryString string, headers map[string]string) (int, error) {
+	var cached *cacheEntry
+	cacheFile := ""
+	if kind := c.cacheKind(endpoint); kind != "" && method == resty.MethodGet {
+		cacheFile = c.cache.path(c, kind, endpoint, queryString)
+		entry, fresh := c.cache.get(cacheFile, kind)
+		if fresh {
+			logrus.Debugf("%s: %s Response from cache\n", method, endpoint)
+			return 0, json.Unmarshal(entry.Body, response)
+		}
+		if entry != nil && entry.ETag != "" {
+			cached = entry
+			revalidate := map[string]string{"If-None-Match": entry.ETag}
+			for k, v := range headers {
+				revalidate[k] = v
+			}
+			headers = revalidate
+		}
+	} else if kind != "" {
+		defer c.cache.invalidate(c, kind, endpoint)
+	}
+
+	var res *resty.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		release, ctxErr := c.acquire(ctx)
+		if ctxErr != nil {
+			return 1, ctxErr
+		}
+		res, err = c.request(ctx, payload, queryString, headers).Execute(method, endpoint)
+		release()
+		if ctx.Err() != nil {
+			// request was cancelled, there is no point in logging transport error
+			return 1, ctx.Err()
+		}
+		logrus.Debugf("%s: %s Response: %d %s\n", method, endpoint, res.StatusCode(), string(res.Body()))
+		wait, retry := c.retryPolicy.backoff(method, attempt, res, err)
+		if !retry {
+			break
+		}
+		logrus.Warnf("%s: %s failed, retrying in %s (%d/%d)\n", method, endpoint, wait, attempt+1, c.retryPolicy.MaxRetries)
+		if err := c.sleep(ctx, wait); err != nil {
+			return 1, err
+		}
+	}
+	if err != nil {
+		logrus.Errorln(err)
+		return 1, err
+	}
+
+	if res.StatusCode() == http.StatusNotModified && cached != nil {
+		c.storeResponse(cacheFile, *cached)
+		return 0, json.Unmarshal(cached.Body, response)
+	}
+
+	if res.StatusCode() >= 400 {
+		return res.StatusCode(), newAPIError(res.StatusCode(), method, endpoint, res.Body())
+	}
+
+	if res.StatusCode() == 204 {
+		return 204, nil
+	}
+
+	if response == nil {
+		// caller doesn't read response body, e.g. of created issue link
+		return 0, nil
+	}
+
+	jsonErr := json.Unmarshal(res.Body(), response)
+
+	if jsonErr != nil {
+		logrus.Errorf("StatusCode: %d\nServer responded with invalid JSON: %s\nResponse: %s\n", res.StatusCode(), jsonErr, string(res.Body()))
+		return 1, errors.New("unmarshalling error")
+	}
+
+	if cacheFile != "" {
+		c.storeResponse(cacheFile, cacheEntry{ETag: res.Header().Get("ETag"), Body: res.Body()})
+	}
+	return 0, nil
+}
+
+// sleepContext waits given time. It returns early with context error when context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
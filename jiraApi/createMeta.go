@@ -0,0 +1,154 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// GetCreateIssueTypes method returns issue types which user can create in project. Project metadata endpoint
+// is used when server doesn't support createmeta with project keys, e.g. JIRA Server 9
+func (c *Client) GetCreateIssueTypes(ctx context.Context, projectKey string) ([]models.IssueTypeMeta, error) {
+	issueTypes, _, err := c.getCreateIssueTypes(ctx, projectKey)
+	return issueTypes, err
+}
+
+// getCreateIssueTypes returns issue types of project and false when they were read from project metadata endpoint
+func (c *Client) getCreateIssueTypes(ctx context.Context, projectKey string) ([]models.IssueTypeMeta, bool, error) {
+	issueTypes, err := c.createMetaIssueTypes(ctx, projectKey, url.Values{"projectKeys": {projectKey}})
+	var apiError *APIError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusNotFound {
+		return issueTypes, true, err
+	}
+	issueTypes = make([]models.IssueTypeMeta, 0)
+	it := c.NewPageIterator(ctx, fmt.Sprintf("rest/api/2/issue/createmeta/%s/issuetypes", projectKey), nil, "values", 0)
+	for it.Next() {
+		page := make([]models.IssueTypeMeta, 0)
+		if err := it.Decode(&page); err != nil {
+			return issueTypes, false, err
+		}
+		issueTypes = append(issueTypes, page...)
+	}
+	return issueTypes, false, it.Err()
+}
+
+// GetCreateMeta method returns fields of create screen of issue type given by name or id in project.
+// Names are matched case insensitive
+func (c *Client) GetCreateMeta(ctx context.Context, projectKey string, issueType string) (models.IssueTypeMeta, error) {
+	issueTypes, legacy, err := c.getCreateIssueTypes(ctx, projectKey)
+	if err != nil {
+		return models.IssueTypeMeta{}, err
+	}
+	meta, names := models.IssueTypeMeta{}, make([]string, 0, len(issueTypes))
+	for _, t := range issueTypes {
+		names = append(names, t.Name)
+		if t.Id == issueType || strings.EqualFold(t.Name, issueType) {
+			meta = t
+		}
+	}
+	if meta.Id == "" {
+		return meta, fmt.Errorf("%w: %s in project %s, available: %s", ErrIssueTypeNotAvailable, issueType, projectKey, strings.Join(names, ", "))
+	}
+	if legacy {
+		query := url.Values{"projectKeys": {projectKey}, "issuetypeIds": {meta.Id}, "expand": {"projects.issuetypes.fields"}}
+		issueTypes, err = c.createMetaIssueTypes(ctx, projectKey, query)
+		if err != nil || len(issueTypes) == 0 {
+			return meta, err
+		}
+		return issueTypes[0], nil
+	}
+	meta.Fields = make(map[string]models.FieldMeta)
+	it := c.NewPageIterator(ctx, fmt.Sprintf("rest/api/2/issue/createmeta/%s/issuetypes/%s", projectKey, meta.Id), nil, "values", 0)
+	for it.Next() {
+		page := make([]models.FieldMeta, 0)
+		if err := it.Decode(&page); err != nil {
+			return meta, err
+		}
+		for _, field := range page {
+			meta.Fields[field.FieldId] = field
+		}
+	}
+	return meta, it.Err()
+}
+
+// createMetaIssueTypes returns issue types of project from createmeta endpoint with given query
+func (c *Client) createMetaIssueTypes(ctx context.Context, projectKey string, query url.Values) ([]models.IssueTypeMeta, error) {
+	meta := models.CreateMeta{}
+	if _, err := c.execute(ctx, resty.MethodGet, "rest/api/2/issue/createmeta", nil, &meta, query.Encode(), nil); err != nil {
+		return nil, err
+	}
+	for _, project := range meta.Projects {
+		if strings.EqualFold(project.Key, projectKey) {
+			return project.IssueTypes, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrProjectNotAvailable, projectKey)
+}
+
+// CheckCreateFields method checks fields of new issue against create metadata of its issue type. Every field
+// must be on create screen, text values given by field name must be allowed and required fields without
+// default value must be set
+func (c *Client) CheckCreateFields(ctx context.Context, meta models.IssueTypeMeta, fields models.Fields, values map[string]string) error {
+	for _, id := range fields.Ids() {
+		if _, ok := meta.Fields[id]; !ok && id != "project" && id != "issuetype" {
+			return fmt.Errorf("%w: %s of issue type %s", ErrFieldNotCreatable, fieldName(meta, id), meta.Name)
+		}
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field, err := c.GetField(ctx, name)
+		if err != nil {
+			return err
+		}
+		fieldMeta := meta.Fields[field.Id]
+		items := []string{values[name]}
+		if fieldMeta.Schema != nil && fieldMeta.Schema.Type == "array" {
+			items = splitItems(values[name])
+		}
+		for _, item := range items {
+			if item != "" && !fieldMeta.Allows(item) {
+				return fmt.Errorf("%w: %s of field %s, allowed: %s", ErrValueNotAllowed, item, field.Name, strings.Join(fieldMeta.AllowedNames(), ", "))
+			}
+		}
+	}
+	missing := meta.MissingFields(fields)
+	for i, id := range missing {
+		missing[i] = fieldName(meta, id)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrRequiredField, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// fieldName returns name of field from metadata or its id when metadata has no name
+func fieldName(meta models.IssueTypeMeta, id string) string {
+	if name := meta.Fields[id].Name; name != "" {
+		return name
+	}
+	return id
+}
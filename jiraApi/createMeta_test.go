@@ -0,0 +1,113 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"errors"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"net/url"
+	"testing"
+)
+
+// registerCreateMeta registers fields and create metadata of Task in project TEST
+func registerCreateMeta() {
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/field",
+		httpmock.NewStringResponder(200, readResponse("./responses/field.json")))
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/createmeta?projectKeys=TEST",
+		httpmock.NewStringResponder(200, readResponse("./responses/issue/createmeta/TEST.json")))
+	query := url.Values{"projectKeys": {"TEST"}, "issuetypeIds": {"10001"}, "expand": {"projects.issuetypes.fields"}}
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/createmeta?"+query.Encode(),
+		httpmock.NewStringResponder(200, readResponse("./responses/issue/createmeta/TEST-10001.json")))
+}
+
+func TestGetCreateMeta(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	registerCreateMeta()
+	ctx := context.Background()
+
+	issueTypes, err := c.GetCreateIssueTypes(ctx, "TEST")
+	assert.NilError(t, err)
+	assert.Equal(t, len(issueTypes), 2)
+	assert.Equal(t, issueTypes[1].Name, "Sub-task")
+	assert.Assert(t, issueTypes[1].Subtask)
+
+	meta, err := c.GetCreateMeta(ctx, "TEST", "task")
+	assert.NilError(t, err)
+	assert.Equal(t, meta.Id, "10001")
+	assert.Equal(t, len(meta.Fields), 7)
+	assert.Assert(t, meta.Fields["priority"].HasDefaultValue)
+	assert.DeepEqual(t, meta.Fields["components"].AllowedNames(), []string{"backend", "frontend"})
+
+	_, err = c.GetCreateMeta(ctx, "TEST", "Epic")
+	assert.Assert(t, errors.Is(err, ErrIssueTypeNotAvailable))
+	assert.Error(t, err, "issue type not available: Epic in project TEST, available: Task, Sub-task")
+}
+
+func TestGetCreateMetaFallback(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/createmeta?projectKeys=TEST",
+		httpmock.NewStringResponder(404, readResponse("./responses/issue/404.json")))
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/createmeta/TEST/issuetypes?maxResults=100&startAt=0",
+		httpmock.NewStringResponder(200, readResponse("./responses/issue/createmeta/TEST/issuetypes.json")))
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/createmeta/TEST/issuetypes/10001?maxResults=100&startAt=0",
+		httpmock.NewStringResponder(200, readResponse("./responses/issue/createmeta/TEST/issuetypes/10001.json")))
+
+	meta, err := c.GetCreateMeta(context.Background(), "TEST", "Task")
+	assert.NilError(t, err)
+	assert.Equal(t, meta.Name, "Task")
+	assert.Equal(t, len(meta.Fields), 2)
+	assert.Equal(t, meta.Fields["priority"].Name, "Priority")
+	assert.Assert(t, meta.Fields["summary"].Required)
+}
+
+func TestCheckCreateFields(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	registerCreateMeta()
+	ctx := context.Background()
+	meta, err := c.GetCreateMeta(ctx, "TEST", "Task")
+	assert.NilError(t, err)
+
+	newFields := func(values map[string]string) models.Fields {
+		fields := models.Fields{Project: &models.Project{Key: "TEST"}, Summary: "Summary"}
+		assert.NilError(t, c.SetFields(ctx, &fields, values))
+		return fields
+	}
+	values := map[string]string{"Component/s": "backend, frontend", "Story Points": "3"}
+	assert.NilError(t, c.CheckCreateFields(ctx, meta, newFields(values), values))
+
+	values = map[string]string{"Story Points": "3"}
+	err = c.CheckCreateFields(ctx, meta, newFields(values), values)
+	assert.Assert(t, errors.Is(err, ErrRequiredField))
+	assert.Error(t, err, "required fields not set: Component/s")
+
+	values = map[string]string{"components": "backend, mobile"}
+	err = c.CheckCreateFields(ctx, meta, newFields(values), values)
+	assert.Assert(t, errors.Is(err, ErrValueNotAllowed))
+	assert.Error(t, err, "value not allowed: mobile of field Component/s, allowed: backend, frontend")
+
+	values = map[string]string{"components": "backend", "labels": "ci"}
+	err = c.CheckCreateFields(ctx, meta, newFields(values), values)
+	assert.Assert(t, errors.Is(err, ErrFieldNotCreatable))
+	assert.Error(t, err, "field can't be set on create: labels of issue type Task")
+}
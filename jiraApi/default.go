@@ -0,0 +1,273 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+)
+
+// DefaultClient is used by package level functions. It is set by Initialize
+var DefaultClient = NewClient("", "", "")
+
+// Initialize method is used to initialize default API client
+func Initialize(serverUrl string, username string, password string, options ...Option) {
+	DefaultClient = NewClient(serverUrl, username, password, options...)
+}
+
+// GetVersions method returns JIRA versions of given project. See Client.GetVersions
+func GetVersions(ctx context.Context, projectKey string, limit int) ([]models.Version, error) {
+	return DefaultClient.GetVersions(ctx, projectKey, limit)
+}
+
+// GetVersion method returns JIRA version details. See Client.GetVersion
+func GetVersion(ctx context.Context, projectKey string, version string) (models.Version, error) {
+	return DefaultClient.GetVersion(ctx, projectKey, version)
+}
+
+// CreateVersion creates new version in specified project. See Client.CreateVersion
+func CreateVersion(ctx context.Context, projectKey string, version string) (createdVersion models.Version, created bool, err error) {
+	return DefaultClient.CreateVersion(ctx, projectKey, version)
+}
+
+// ReleaseVersion method changes project status to "released". See Client.ReleaseVersion
+func ReleaseVersion(ctx context.Context, projectKey string, version string) error {
+	return DefaultClient.ReleaseVersion(ctx, projectKey, version)
+}
+
+// GetProject method returns project details. See Client.GetProject
+func GetProject(ctx context.Context, projectKey string) (models.Project, error) {
+	return DefaultClient.GetProject(ctx, projectKey)
+}
+
+// GetProjects method list all projects. See Client.GetProjects
+func GetProjects(ctx context.Context, limit int) ([]models.Project, error) {
+	return DefaultClient.GetProjects(ctx, limit)
+}
+
+// SetFixVersion method sets fix version of issue. See Client.SetFixVersion
+func SetFixVersion(ctx context.Context, issueKey string, version string) error {
+	return DefaultClient.SetFixVersion(ctx, issueKey, version)
+}
+
+// CreateFixVersion method creates version and deployment issue. See Client.CreateFixVersion
+func CreateFixVersion(ctx context.Context, projectKey string, version string, createDeploymentIssue bool, summary string, description string, issueType string) error {
+	return DefaultClient.CreateFixVersion(ctx, projectKey, version, createDeploymentIssue, summary, description, issueType)
+}
+
+// GetIssue method returns issue details. See Client.GetIssue
+func GetIssue(ctx context.Context, issueKey string) (i models.Issue, error error) {
+	return DefaultClient.GetIssue(ctx, issueKey)
+}
+
+// GetIssueWithOptions method returns issue with selected fields and expansions. See Client.GetIssueWithOptions
+func GetIssueWithOptions(ctx context.Context, issueKey string, options IssueOptions) (models.Issue, error) {
+	return DefaultClient.GetIssueWithOptions(ctx, issueKey, options)
+}
+
+// GetIssueWorkflow method returns issue workflow. See Client.GetIssueWorkflow
+func GetIssueWorkflow(ctx context.Context, issueKey string) (*models.Workflow, error) {
+	return DefaultClient.GetIssueWorkflow(ctx, issueKey)
+}
+
+// GetIssueWorkflowName method returns issue workflow name. See Client.GetIssueWorkflowName
+func GetIssueWorkflowName(ctx context.Context, issueKey string) (name string, error error) {
+	return DefaultClient.GetIssueWorkflowName(ctx, issueKey)
+}
+
+// GetWorkflowScheme method returns workflow scheme of project. See Client.GetWorkflowScheme
+func GetWorkflowScheme(ctx context.Context, projectKey string) (models.WorkflowScheme, error) {
+	return DefaultClient.GetWorkflowScheme(ctx, projectKey)
+}
+
+// GetWorkflowName method returns workflow name of issue type in project. See Client.GetWorkflowName
+func GetWorkflowName(ctx context.Context, projectKey string, issueTypeId string) (string, error) {
+	return DefaultClient.GetWorkflowName(ctx, projectKey, issueTypeId)
+}
+
+// GetWorkflow method returns workflow of issue type in project. See Client.GetWorkflow
+func GetWorkflow(ctx context.Context, projectKey string, issueTypeId string) (*models.Workflow, error) {
+	return DefaultClient.GetWorkflow(ctx, projectKey, issueTypeId)
+}
+
+// GetIssues method returns details of many issues. See Client.GetIssues
+func GetIssues(ctx context.Context, issueKeys []string) ([]models.Issue, map[string]error) {
+	return DefaultClient.GetIssues(ctx, issueKeys)
+}
+
+// GetIssuesWithOptions method returns many issues with selected fields and expansions. See Client.GetIssuesWithOptions
+func GetIssuesWithOptions(ctx context.Context, issueKeys []string, options IssueOptions) ([]models.Issue, map[string]error) {
+	return DefaultClient.GetIssuesWithOptions(ctx, issueKeys, options)
+}
+
+// GetIssuesInVersions method returns issues of given types in version. See Client.GetIssuesInVersions
+func GetIssuesInVersions(ctx context.Context, projectKey string, version string, issueTypes string, limit int) (issuesInVersionList models.IssueList, error error) {
+	return DefaultClient.GetIssuesInVersions(ctx, projectKey, version, issueTypes, limit)
+}
+
+// SearchIssues method returns issues matching JQL query. See Client.SearchIssues
+func SearchIssues(ctx context.Context, jql string, fields []string, limit int) (models.IssueList, error) {
+	return DefaultClient.SearchIssues(ctx, jql, fields, limit)
+}
+
+// GetEditMeta method returns editable fields of issue. See Client.GetEditMeta
+func GetEditMeta(ctx context.Context, issueKey string) (models.EditMeta, error) {
+	return DefaultClient.GetEditMeta(ctx, issueKey)
+}
+
+// UpdateIssue method sends update of issue. See Client.UpdateIssue
+func UpdateIssue(ctx context.Context, issueKey string, update models.IssueUpdate) error {
+	return DefaultClient.UpdateIssue(ctx, issueKey, update)
+}
+
+// EditIssue method applies edits to issue. See Client.EditIssue
+func EditIssue(ctx context.Context, issueKey string, edits []FieldEdit) error {
+	return DefaultClient.EditIssue(ctx, issueKey, edits)
+}
+
+// GetCreateIssueTypes method returns issue types which can be created in project. See Client.GetCreateIssueTypes
+func GetCreateIssueTypes(ctx context.Context, projectKey string) ([]models.IssueTypeMeta, error) {
+	return DefaultClient.GetCreateIssueTypes(ctx, projectKey)
+}
+
+// GetCreateMeta method returns fields of create screen of issue type. See Client.GetCreateMeta
+func GetCreateMeta(ctx context.Context, projectKey string, issueType string) (models.IssueTypeMeta, error) {
+	return DefaultClient.GetCreateMeta(ctx, projectKey, issueType)
+}
+
+// CheckCreateFields method checks fields of new issue against create metadata. See Client.CheckCreateFields
+func CheckCreateFields(ctx context.Context, meta models.IssueTypeMeta, fields models.Fields, values map[string]string) error {
+	return DefaultClient.CheckCreateFields(ctx, meta, fields, values)
+}
+
+// CreateIssues method creates issues with bulk requests. See Client.CreateIssues
+func CreateIssues(ctx context.Context, fieldsList []models.Fields) ([]models.Issue, map[int]error) {
+	return DefaultClient.CreateIssues(ctx, fieldsList)
+}
+
+// LinkIssues method links issues. See Client.LinkIssues
+func LinkIssues(ctx context.Context, linkType string, outwardKey string, inwardKey string) error {
+	return DefaultClient.LinkIssues(ctx, linkType, outwardKey, inwardKey)
+}
+
+// TemplateFields method returns fields of issue created from template. See Client.TemplateFields
+func TemplateFields(ctx context.Context, t IssueTemplate) (models.Fields, error) {
+	return DefaultClient.TemplateFields(ctx, t)
+}
+
+// CreateFromTemplates method creates issues from templates. See Client.CreateFromTemplates
+func CreateFromTemplates(ctx context.Context, templates []IssueTemplate) []TemplateResult {
+	return DefaultClient.CreateFromTemplates(ctx, templates)
+}
+
+// Search method returns issues found with query. See Client.Search
+func Search(ctx context.Context, q SearchQuery, fields []string, limit int) (models.IssueList, error) {
+	return DefaultClient.Search(ctx, q, fields, limit)
+}
+
+// SearchJQL method returns JQL of query. See Client.SearchJQL
+func SearchJQL(ctx context.Context, q SearchQuery) (string, error) {
+	return DefaultClient.SearchJQL(ctx, q)
+}
+
+// GetFilter method returns saved filter. See Client.GetFilter
+func GetFilter(ctx context.Context, idOrName string) (models.Filter, error) {
+	return DefaultClient.GetFilter(ctx, idOrName)
+}
+
+// AddWorklog method add worklog to issue. See Client.AddWorklog
+func AddWorklog(ctx context.Context, key string, min uint64, com string, date string, time string) (models.WorklogResp, error) {
+	return DefaultClient.AddWorklog(ctx, key, min, com, date, time)
+}
+
+// ListWorklog method lists worklogs of issue. See Client.ListWorklog
+func ListWorklog(ctx context.Context, key string, limit int) (worklogs models.WorklogList, error error) {
+	return DefaultClient.ListWorklog(ctx, key, limit)
+}
+
+// DeleteWorklog method deletes worklog from issue. See Client.DeleteWorklog
+func DeleteWorklog(ctx context.Context, key string, id string) (status int, error error) {
+	return DefaultClient.DeleteWorklog(ctx, key, id)
+}
+
+// DeleteWorklogForUser method deletes all user worklogs from issue. See Client.DeleteWorklogForUser
+func DeleteWorklogForUser(ctx context.Context, user string, key string) (sumOk int, sumError int, error error) {
+	return DefaultClient.DeleteWorklogForUser(ctx, user, key)
+}
+
+// TransitionIssue method executes issue transition. See Client.TransitionIssue
+func TransitionIssue(ctx context.Context, workflowPath string, issueKey string, targetStatus string, excludeStatus string) (status int, error error) {
+	return DefaultClient.TransitionIssue(ctx, workflowPath, issueKey, targetStatus, excludeStatus)
+}
+
+// GetTransitionByName method returns transition details from issue. See Client.GetTransitionByName
+func GetTransitionByName(ctx context.Context, issueKey string, transitionName string) (models.Transition, error) {
+	return DefaultClient.GetTransitionByName(ctx, issueKey, transitionName)
+}
+
+// GetTransitions method returns available transitions for issue. See Client.GetTransitions
+func GetTransitions(ctx context.Context, issueKey string) ([]models.Transition, error) {
+	return DefaultClient.GetTransitions(ctx, issueKey)
+}
+
+// ReadWorkflow method loads Workflow definition from env var, http url or file. See Client.ReadWorkflow
+func ReadWorkflow(ctx context.Context, workflowPath string) (WorkflowTransitionsMap, error) {
+	return DefaultClient.ReadWorkflow(ctx, workflowPath)
+}
+
+// TestTransitions method run through all transitions to test Workflow definition. See Client.TestTransitions
+func TestTransitions(ctx context.Context, workflowPath string, issueKey string) error {
+	return DefaultClient.TestTransitions(ctx, workflowPath, issueKey)
+}
+
+// CreateIssue method creates new issue. See Client.CreateIssue
+func CreateIssue(ctx context.Context, projectKey string, summary string, description string, issueType string, version *models.Version) (models.Issue, error) {
+	return DefaultClient.CreateIssue(ctx, projectKey, summary, description, issueType, version)
+}
+
+// CreateIssueWithFields method creates issue with given fields. See Client.CreateIssueWithFields
+func CreateIssueWithFields(ctx context.Context, fields models.Fields) (models.Issue, error) {
+	return DefaultClient.CreateIssueWithFields(ctx, fields)
+}
+
+// GetFields method returns system and custom issue fields. See Client.GetFields
+func GetFields(ctx context.Context) ([]models.Field, error) {
+	return DefaultClient.GetFields(ctx)
+}
+
+// GetField method returns field with given id or name. See Client.GetField
+func GetField(ctx context.Context, nameOrId string) (models.Field, error) {
+	return DefaultClient.GetField(ctx, nameOrId)
+}
+
+// SetFields method sets field values given by field name. See Client.SetFields
+func SetFields(ctx context.Context, fields *models.Fields, values map[string]string) error {
+	return DefaultClient.SetFields(ctx, fields, values)
+}
+
+// ForEach method calls fn for every key in limited number of goroutines. See Client.ForEach
+func ForEach(ctx context.Context, keys []string, fn func(key string)) {
+	DefaultClient.ForEach(ctx, keys, fn)
+}
+
+// Myself method returns details of authenticated user. See Client.Myself
+func Myself(ctx context.Context) (models.Author, error) {
+	return DefaultClient.Myself(ctx)
+}
+
+// CurrentUserId method returns identity of authenticated user. See Client.CurrentUserId
+func CurrentUserId(ctx context.Context) (string, error) {
+	return DefaultClient.CurrentUserId(ctx)
+}
@@ -0,0 +1,127 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"fmt"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/resty.v1"
+	"strings"
+)
+
+// Update operations of issue fields
+const (
+	OperationSet    = "set"
+	OperationAdd    = "add"
+	OperationRemove = "remove"
+)
+
+// FieldEdit type represents update operation of issue field given by id or name with text value, e.g. add label
+type FieldEdit struct {
+	Field     string
+	Operation string
+	Value     string
+}
+
+// GetEditMeta method returns fields of issue which can be edited with operations and values they accept
+func (c *Client) GetEditMeta(ctx context.Context, issueKey string) (models.EditMeta, error) {
+	meta := models.EditMeta{}
+	_, err := c.execute(ctx, resty.MethodGet, fmt.Sprintf("rest/api/2/issue/%s/editmeta", issueKey), nil, &meta, "", nil)
+	return meta, err
+}
+
+// IssueUpdate method returns update of issue built from edits. Fields, operations and allowed values
+// are checked against edit metadata of issue. Values are encoded like in FieldValue, set replaces whole array
+// and add or remove are applied to every comma separated item
+func (c *Client) IssueUpdate(ctx context.Context, issueKey string, edits []FieldEdit) (models.IssueUpdate, error) {
+	update := models.IssueUpdate{}
+	meta, err := c.GetEditMeta(ctx, issueKey)
+	if err != nil {
+		return update, err
+	}
+	flavor, err := c.Flavor(ctx)
+	if err != nil {
+		return update, err
+	}
+	for _, edit := range edits {
+		field, err := c.GetField(ctx, edit.Field)
+		if err != nil {
+			return update, err
+		}
+		fieldMeta, ok := meta.Fields[field.Id]
+		if !ok {
+			return update, fmt.Errorf("%w: %s", ErrFieldNotEditable, field.Name)
+		}
+		if !fieldMeta.Supports(edit.Operation) {
+			return update, fmt.Errorf("%w: %s of field %s, supported: %s", ErrOperationNotSupported, edit.Operation, field.Name, strings.Join(fieldMeta.Operations, ", "))
+		}
+		if fieldMeta.Schema != nil {
+			field.Schema = fieldMeta.Schema
+		}
+		isArray := field.Schema != nil && field.Schema.Type == "array"
+		items := []string{edit.Value}
+		if isArray {
+			items = splitItems(edit.Value)
+		}
+		for _, item := range items {
+			if item != "" && !fieldMeta.Allows(item) {
+				return update, fmt.Errorf("%w: %s of field %s, allowed: %s", ErrValueNotAllowed, item, field.Name, strings.Join(fieldMeta.AllowedNames(), ", "))
+			}
+		}
+		if edit.Operation == OperationSet || !isArray {
+			value, err := c.FieldValue(ctx, field, edit.Value)
+			if err != nil {
+				return update, err
+			}
+			update.Add(field.Id, edit.Operation, value)
+			continue
+		}
+		for _, item := range items {
+			value, err := fieldItemValue(flavor, field, field.Schema.Items, item)
+			if err != nil {
+				return update, err
+			}
+			update.Add(field.Id, edit.Operation, value)
+		}
+	}
+	return update, nil
+}
+
+// splitItems returns non empty comma separated items of array value
+func splitItems(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// UpdateIssue method sends update operations of issue fields
+func (c *Client) UpdateIssue(ctx context.Context, issueKey string, update models.IssueUpdate) error {
+	_, err := c.execute(ctx, resty.MethodPut, fmt.Sprintf("rest/api/2/issue/%s", issueKey), update, nil, "", nil)
+	return err
+}
+
+// EditIssue method applies edits to issue. See Client.IssueUpdate
+func (c *Client) EditIssue(ctx context.Context, issueKey string, edits []FieldEdit) error {
+	update, err := c.IssueUpdate(ctx, issueKey, edits)
+	if err != nil {
+		return err
+	}
+	return c.UpdateIssue(ctx, issueKey, update)
+}
@@ -0,0 +1,89 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// registerEditMeta registers fields and edit metadata of TEST-1
+func registerEditMeta() {
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/field",
+		httpmock.NewStringResponder(200, readResponse("./responses/field.json")))
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/TEST-1/editmeta",
+		httpmock.NewStringResponder(200, readResponse("./responses/issue/TEST-1/editmeta.json")))
+}
+
+func TestEditIssue(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	registerEditMeta()
+	var body map[string]interface{}
+	httpmock.RegisterResponder("PUT", "https://jira.example.com/rest/api/2/issue/TEST-1",
+		func(req *http.Request) (*http.Response, error) {
+			payload, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(payload, &body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(204, ""), nil
+		})
+
+	err := c.EditIssue(context.Background(), "TEST-1", []FieldEdit{
+		{Field: "summary", Operation: OperationSet, Value: "New summary"},
+		{Field: "Priority", Operation: OperationSet, Value: "highest"},
+		{Field: "labels", Operation: OperationAdd, Value: "ci, release"},
+		{Field: "labels", Operation: OperationRemove, Value: "draft"},
+		{Field: "Fix Version/s", Operation: OperationSet, Value: "1.0.0,1.1.0"},
+		{Field: "Story Points", Operation: OperationSet, Value: "3"},
+		{Field: "Due Date", Operation: OperationSet, Value: ""},
+	})
+	assert.NilError(t, err)
+	expected := map[string]interface{}{}
+	assert.NilError(t, json.Unmarshal([]byte(`{"update": {
+		"summary": [{"set": "New summary"}],
+		"priority": [{"set": {"name": "highest"}}],
+		"labels": [{"add": "ci"}, {"add": "release"}, {"remove": "draft"}],
+		"fixVersions": [{"set": [{"name": "1.0.0"}, {"name": "1.1.0"}]}],
+		"customfield_10002": [{"set": 3}],
+		"duedate": [{"set": null}]
+	}}`), &expected))
+	assert.DeepEqual(t, body, expected)
+}
+
+func TestIssueUpdateErrors(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	registerEditMeta()
+	ctx := context.Background()
+
+	_, err := c.IssueUpdate(ctx, "TEST-1", []FieldEdit{{Field: "Team", Operation: OperationSet, Value: "x"}})
+	assert.ErrorContains(t, err, "field name Team is ambiguous")
+	_, err = c.IssueUpdate(ctx, "TEST-1", []FieldEdit{{Field: "assignee", Operation: OperationSet, Value: "john"}})
+	assert.Assert(t, errors.Is(err, ErrFieldNotEditable))
+	_, err = c.IssueUpdate(ctx, "TEST-1", []FieldEdit{{Field: "summary", Operation: OperationAdd, Value: "x"}})
+	assert.Assert(t, errors.Is(err, ErrOperationNotSupported))
+	_, err = c.IssueUpdate(ctx, "TEST-1", []FieldEdit{{Field: "fixVersions", Operation: OperationAdd, Value: "2.0.0"}})
+	assert.Assert(t, errors.Is(err, ErrValueNotAllowed))
+	assert.Error(t, err, "value not allowed: 2.0.0 of field Fix Version/s, allowed: 1.0.0, 1.1.0")
+}
@@ -0,0 +1,129 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sotomskir/jira-cli/graph"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownStatus is returned when workflow has no status with given name
+var ErrUnknownStatus = graph.ErrUnknownStatus
+
+// ErrNoPath is returned when target status can't be reached with workflow transitions
+var ErrNoPath = graph.ErrNoPath
+
+// ErrTransitionNotAvailable is returned when transition can't be executed in current status of issue
+var ErrTransitionNotAvailable = errors.New("transition not available")
+
+// ErrExcludedStatus is returned when issue is not transitioned because it has excluded status
+var ErrExcludedStatus = errors.New("issue has excluded status")
+
+// ErrFixVersionSet is returned when fix version is not set because issue already has one
+var ErrFixVersionSet = errors.New("fix version is already set")
+
+// ErrFieldNotEditable is returned when field is not on edit screen of issue
+var ErrFieldNotEditable = errors.New("field can't be edited")
+
+// ErrOperationNotSupported is returned when field doesn't accept update operation
+var ErrOperationNotSupported = errors.New("operation not supported")
+
+// ErrValueNotAllowed is returned when value is not one of values allowed for field
+var ErrValueNotAllowed = errors.New("value not allowed")
+
+// ErrProjectNotAvailable is returned when project doesn't exist or user can't create issues in it
+var ErrProjectNotAvailable = errors.New("project not available")
+
+// ErrIssueTypeNotAvailable is returned when issue type can't be created in project
+var ErrIssueTypeNotAvailable = errors.New("issue type not available")
+
+// ErrFieldNotCreatable is returned when field is not on create screen of issue type
+var ErrFieldNotCreatable = errors.New("field can't be set on create")
+
+// ErrRequiredField is returned when required fields of new issue are not set
+var ErrRequiredField = errors.New("required fields not set")
+
+// TransitionError type represents failed transition of issue to target status.
+// It wraps ErrUnknownStatus, ErrNoPath, ErrTransitionNotAvailable or error of request
+type TransitionError struct {
+	IssueKey string
+	Status   string
+	Target   string
+	Err      error
+}
+
+// Error method returns issue key, statuses and reason of failure
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: cannot transition from '%s' to '%s': %s", e.IssueKey, e.Status, e.Target, e.Err)
+}
+
+// Unwrap method returns reason of failure
+func (e *TransitionError) Unwrap() error {
+	return e.Err
+}
+
+// APIError type represents error response returned by JIRA REST API
+type APIError struct {
+	StatusCode    int               `json:"-"`
+	Method        string            `json:"-"`
+	Endpoint      string            `json:"-"`
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
+
+// newAPIError creates APIError from response body, body which is not JSON error is ignored
+func newAPIError(statusCode int, method string, endpoint string, body []byte) *APIError {
+	apiError := APIError{}
+	if err := json.Unmarshal(body, &apiError); err != nil {
+		apiError = APIError{}
+	}
+	apiError.StatusCode = statusCode
+	apiError.Method = method
+	apiError.Endpoint = endpoint
+	return &apiError
+}
+
+// Error method returns status code followed by all messages returned by server
+func (e *APIError) Error() string {
+	messages := e.Messages()
+	if len(messages) == 0 {
+		return fmt.Sprintf("http error: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("http error: %d: %s", e.StatusCode, strings.Join(messages, "; "))
+}
+
+// Messages method returns error messages followed by field errors sorted by field name
+func (e *APIError) Messages() []string {
+	messages := make([]string, 0, len(e.ErrorMessages)+len(e.Errors))
+	messages = append(messages, e.ErrorMessages...)
+	for _, field := range e.Fields() {
+		messages = append(messages, fmt.Sprintf("%s: %s", field, e.Errors[field]))
+	}
+	return messages
+}
+
+// Fields method returns sorted names of fields rejected by server
+func (e *APIError) Fields() []string {
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,149 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"fmt"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/resty.v1"
+	"strconv"
+	"strings"
+)
+
+// GetFields method returns system and custom issue fields. Fields are loaded once per client
+func (c *Client) GetFields(ctx context.Context) ([]models.Field, error) {
+	c.fieldsMu.Lock()
+	defer c.fieldsMu.Unlock()
+	if c.fields != nil {
+		return c.fields, nil
+	}
+	fields := make([]models.Field, 0)
+	if _, err := c.execute(ctx, resty.MethodGet, "rest/api/2/field", nil, &fields, "", nil); err != nil {
+		return nil, err
+	}
+	c.fields = fields
+	return fields, nil
+}
+
+// GetField method returns field with given id or name, e.g. "customfield_10002" or "Story Points".
+// Names are matched case insensitive, name used by many fields must be replaced with field id
+func (c *Client) GetField(ctx context.Context, nameOrId string) (models.Field, error) {
+	fields, err := c.GetFields(ctx)
+	if err != nil {
+		return models.Field{}, err
+	}
+	matches := make([]models.Field, 0)
+	for _, f := range fields {
+		if f.Id == nameOrId {
+			return f, nil
+		}
+		if strings.EqualFold(f.Name, nameOrId) {
+			matches = append(matches, f)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return models.Field{}, fmt.Errorf("unknown field: %s", nameOrId)
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, 0, len(matches))
+	for _, f := range matches {
+		ids = append(ids, f.Id)
+	}
+	return models.Field{}, fmt.Errorf("field name %s is ambiguous, use one of ids: %s", nameOrId, strings.Join(ids, ", "))
+}
+
+// FieldValue method encodes text value in format expected by JIRA for field type: numbers, options, users, versions
+// and arrays of them. Array items are separated with comma, empty value clears field
+func (c *Client) FieldValue(ctx context.Context, field models.Field, value string) (interface{}, error) {
+	flavor, err := c.Flavor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schema := models.FieldSchema{Type: "string"}
+	if field.Schema != nil {
+		schema = *field.Schema
+	}
+	if schema.Type != "array" {
+		if value == "" {
+			return nil, nil
+		}
+		return fieldItemValue(flavor, field, schema.Type, value)
+	}
+	items := make([]interface{}, 0)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		v, err := fieldItemValue(flavor, field, schema.Items, item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
+
+// richTextFields lists system fields which are documents on JIRA Cloud
+var richTextFields = map[string]bool{"description": true, "environment": true}
+
+// fieldItemValue returns single value of field type
+func fieldItemValue(flavor Flavor, field models.Field, fieldType string, value string) (interface{}, error) {
+	switch fieldType {
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %s is not a number", field.Name, value)
+		}
+		return n, nil
+	case "option", "option-with-child":
+		return map[string]string{"value": value}, nil
+	case "user":
+		if flavor == FlavorCloud {
+			return map[string]string{"accountId": value}, nil
+		}
+		return map[string]string{"name": value}, nil
+	case "version", "component", "priority", "resolution", "group", "issuetype", "securitylevel":
+		return map[string]string{"name": value}, nil
+	case "project", "issuelink":
+		return map[string]string{"key": value}, nil
+	case "string":
+		if flavor == FlavorCloud && field.Schema != nil && (strings.HasSuffix(field.Schema.Custom, ":textarea") || richTextFields[field.Schema.System]) {
+			return models.NewDocument(value), nil
+		}
+	}
+	return value, nil
+}
+
+// SetFields method resolves field names and sets their encoded text values. See GetField and FieldValue
+func (c *Client) SetFields(ctx context.Context, fields *models.Fields, values map[string]string) error {
+	for name, value := range values {
+		field, err := c.GetField(ctx, name)
+		if err != nil {
+			return err
+		}
+		v, err := c.FieldValue(ctx, field, value)
+		if err != nil {
+			return err
+		}
+		if err := fields.Set(field.Id, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestGetField(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/field",
+		httpmock.NewStringResponder(200, readResponse("./responses/field.json")))
+
+	field, err := c.GetField(context.Background(), "story points")
+	assert.NilError(t, err)
+	assert.Equal(t, field.Id, "customfield_10002")
+	field, err = c.GetField(context.Background(), "customfield_10006")
+	assert.NilError(t, err)
+	assert.Equal(t, field.Name, "Team")
+	_, err = c.GetField(context.Background(), "Team")
+	assert.Error(t, err, "field name Team is ambiguous, use one of ids: customfield_10003, customfield_10006")
+	_, err = c.GetField(context.Background(), "Sprint")
+	assert.Error(t, err, "unknown field: Sprint")
+	assert.Equal(t, httpmock.GetTotalCallCount(), 1)
+}
+
+func TestFieldValue(t *testing.T) {
+	fields := make([]models.Field, 0)
+	assert.NilError(t, json.Unmarshal([]byte(readResponse("./responses/field.json")), &fields))
+	server := NewClient("https://jira.example.com", "user", "pass")
+	cloud := NewClient("https://example.atlassian.net", "user", "token", WithFlavor(FlavorCloud))
+	ctx := context.Background()
+
+	value, err := server.FieldValue(ctx, fields[1], "5.5")
+	assert.NilError(t, err)
+	assert.Equal(t, value, 5.5)
+	_, err = server.FieldValue(ctx, fields[1], "five")
+	assert.Error(t, err, "field Story Points: five is not a number")
+	value, err = server.FieldValue(ctx, fields[2], "Red")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, value, map[string]string{"value": "Red"})
+	value, err = server.FieldValue(ctx, fields[4], "john, jane")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, value, []interface{}{map[string]string{"name": "john"}, map[string]string{"name": "jane"}})
+	value, err = cloud.FieldValue(ctx, fields[4], "5b10ac8d82e05b22cc7d4ef5")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, value, []interface{}{map[string]string{"accountId": "5b10ac8d82e05b22cc7d4ef5"}})
+	value, err = server.FieldValue(ctx, fields[3], "notes")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "notes")
+	value, err = cloud.FieldValue(ctx, fields[3], "notes")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, value, models.NewDocument("notes"))
+	value, err = server.FieldValue(ctx, fields[2], "")
+	assert.NilError(t, err)
+	assert.Assert(t, value == nil)
+}
+
+func TestCreateIssueWithFields(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/field",
+		httpmock.NewStringResponder(200, readResponse("./responses/field.json")))
+	var payload map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", "https://jira.example.com/rest/api/2/issue",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			json.Unmarshal(body, &payload)
+			return httpmock.NewStringResponse(201, `{"id":"10109","key":"TEST-16"}`), nil
+		})
+
+	fields := models.Fields{Summary: "test", Project: &models.Project{Key: "TEST"}, IssueType: &models.IssueType{Name: "Task"}}
+	err := c.SetFields(context.Background(), &fields, map[string]string{"Story Points": "3", "customfield_10003": "Red"})
+	assert.NilError(t, err)
+	issue, err := c.CreateIssueWithFields(context.Background(), fields)
+
+	assert.NilError(t, err)
+	assert.Equal(t, issue.Key, "TEST-16")
+	assert.Equal(t, payload["fields"]["summary"], "test")
+	assert.Equal(t, payload["fields"]["customfield_10002"], 3.0)
+	assert.DeepEqual(t, payload["fields"]["customfield_10003"], map[string]interface{}{"value": "Red"})
+}
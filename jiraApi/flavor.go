@@ -0,0 +1,109 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"fmt"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/resty.v1"
+	"strings"
+)
+
+// Flavor type selects REST API version and user identity used by Client
+type Flavor string
+
+const (
+	// FlavorAuto detects flavor from deployment type reported by server
+	FlavorAuto Flavor = "auto"
+	// FlavorServer uses REST API v2, users are identified by name
+	FlavorServer Flavor = "server"
+	// FlavorCloud uses REST API v3, users are identified by account id and rich text is sent in Atlassian Document Format
+	FlavorCloud Flavor = "cloud"
+)
+
+// ParseFlavor method returns flavor of given name
+func ParseFlavor(name string) (Flavor, error) {
+	switch flavor := Flavor(strings.ToLower(name)); flavor {
+	case FlavorAuto, FlavorServer, FlavorCloud:
+		return flavor, nil
+	case "":
+		return FlavorAuto, nil
+	}
+	return "", fmt.Errorf("unknown API flavor: %s, expected one of: auto, server, cloud", name)
+}
+
+// WithFlavor option sets API flavor. Default is FlavorServer
+func WithFlavor(flavor Flavor) Option {
+	return func(c *Client) {
+		c.flavor = flavor
+	}
+}
+
+// Flavor method returns API flavor used by client. Auto flavor is detected with first call
+func (c *Client) Flavor(ctx context.Context) (Flavor, error) {
+	c.flavorMu.Lock()
+	defer c.flavorMu.Unlock()
+	if c.flavor != FlavorAuto {
+		return c.flavor, nil
+	}
+	info := models.ServerInfo{}
+	if _, err := c.send(ctx, resty.MethodGet, "rest/api/2/serverInfo", nil, &info, "", nil); err != nil {
+		return "", fmt.Errorf("cannot detect API flavor: %w", err)
+	}
+	if strings.EqualFold(info.DeploymentType, "Cloud") {
+		c.flavor = FlavorCloud
+	} else {
+		c.flavor = FlavorServer
+	}
+	return c.flavor, nil
+}
+
+// apiEndpoint returns endpoint of REST API version used by flavor
+func apiEndpoint(flavor Flavor, endpoint string) string {
+	if flavor == FlavorCloud && strings.HasPrefix(endpoint, "rest/api/2/") {
+		return "rest/api/3/" + strings.TrimPrefix(endpoint, "rest/api/2/")
+	}
+	return endpoint
+}
+
+// userId returns identity of user in given flavor: account id on JIRA Cloud and user name on JIRA Server
+func userId(flavor Flavor, user models.Author) string {
+	if flavor == FlavorCloud {
+		return user.AccountId
+	}
+	return user.Name
+}
+
+// Myself method returns details of authenticated user
+func (c *Client) Myself(ctx context.Context) (models.Author, error) {
+	user := models.Author{}
+	_, err := c.execute(ctx, resty.MethodGet, "rest/api/2/myself", nil, &user, "", nil)
+	return user, err
+}
+
+// CurrentUserId method returns identity of authenticated user: account id on JIRA Cloud and user name on JIRA Server.
+// User name is read from server when client authenticates with token
+func (c *Client) CurrentUserId(ctx context.Context) (string, error) {
+	flavor, err := c.Flavor(ctx)
+	if err != nil {
+		return "", err
+	}
+	if flavor == FlavorServer && c.username != "" {
+		return c.username, nil
+	}
+	user, err := c.Myself(ctx)
+	return userId(flavor, user), err
+}
@@ -0,0 +1,129 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestFlavorAutoDetect(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://example.atlassian.net", "user", "token", WithFlavor(FlavorAuto))
+	httpmock.RegisterResponder("GET", "https://example.atlassian.net/rest/api/2/serverInfo",
+		httpmock.NewStringResponder(200, readResponse("./responses/cloud/serverInfo.json")))
+	httpmock.RegisterResponder("GET", "https://example.atlassian.net/rest/api/3/issue/TEST-1",
+		httpmock.NewStringResponder(200, readResponse("./responses/cloud/issue/TEST-1.json")))
+
+	issue, err := c.GetIssue(context.Background(), "TEST-1")
+	assert.NilError(t, err)
+	_, err = c.GetIssue(context.Background(), "TEST-1")
+	assert.NilError(t, err)
+	flavor, err := c.Flavor(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, flavor, FlavorCloud)
+	assert.Equal(t, httpmock.GetTotalCallCount(), 3)
+	assert.Equal(t, issue.Fields.Summary, "Cloud issue")
+	assert.Equal(t, issue.Fields.Description, models.RichText("First line\nsecond line with bold\n\n@Robert"))
+}
+
+func TestFlavorServerDetect(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass", WithFlavor(FlavorAuto))
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/serverInfo",
+		httpmock.NewStringResponder(200, `{"version":"8.5.0","deploymentType":"Server"}`))
+
+	flavor, err := c.Flavor(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, flavor, FlavorServer)
+}
+
+func TestCreateIssueCloud(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://example.atlassian.net", "user", "token", WithFlavor(FlavorCloud))
+	var payload map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", "https://example.atlassian.net/rest/api/3/issue",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			json.Unmarshal(body, &payload)
+			return httpmock.NewStringResponse(201, `{"id":"10000","key":"TEST-1"}`), nil
+		})
+
+	issue, err := c.CreateIssue(context.Background(), "TEST", "summary", "first\nsecond", "Task", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, issue.Key, "TEST-1")
+	assert.Equal(t, payload["fields"]["summary"], "summary")
+	description, _ := json.Marshal(payload["fields"]["description"])
+	assert.Equal(t, string(description), `{"content":[{"content":[{"text":"first","type":"text"},{"type":"hardBreak"},{"text":"second","type":"text"}],"type":"paragraph"}],"type":"doc","version":1}`)
+}
+
+func TestAddWorklogCloud(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://example.atlassian.net", "user", "token", WithFlavor(FlavorCloud))
+	var payload map[string]interface{}
+	httpmock.RegisterResponder("POST", "https://example.atlassian.net/rest/api/3/issue/TEST-1/worklog",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			json.Unmarshal(body, &payload)
+			return httpmock.NewStringResponse(201, `{"id":"10100"}`), nil
+		})
+
+	_, err := c.AddWorklog(context.Background(), "TEST-1", 60, "comment", "", "")
+	assert.NilError(t, err)
+	assert.Equal(t, payload["timeSpentSeconds"], float64(3600))
+	comment, _ := json.Marshal(payload["comment"])
+	assert.Equal(t, string(comment), `{"content":[{"content":[{"text":"comment","type":"text"}],"type":"paragraph"}],"type":"doc","version":1}`)
+}
+
+func TestDeleteWorklogForUserCloud(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://example.atlassian.net", "robert@example.com", "token", WithFlavor(FlavorCloud))
+	httpmock.RegisterResponder("GET", "https://example.atlassian.net/rest/api/3/myself",
+		httpmock.NewStringResponder(200, readResponse("./responses/cloud/myself.json")))
+	httpmock.RegisterResponder("GET", "https://example.atlassian.net/rest/api/3/issue/TEST-1/worklog",
+		httpmock.NewStringResponder(200, readResponse("./responses/cloud/issue/TEST-1/worklog.json")))
+	httpmock.RegisterResponder("DELETE", "https://example.atlassian.net/rest/api/3/issue/TEST-1/worklog/10100",
+		httpmock.NewStringResponder(204, ""))
+
+	user, err := c.CurrentUserId(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, user, "5b10ac8d82e05b22cc7d4ef5")
+	sumOk, sumError, err := c.DeleteWorklogForUser(context.Background(), user, "TEST-1")
+	assert.NilError(t, err)
+	assert.Equal(t, sumOk, 1)
+	assert.Equal(t, sumError, 0)
+}
+
+func TestParseFlavor(t *testing.T) {
+	flavor, err := ParseFlavor("Cloud")
+	assert.NilError(t, err)
+	assert.Equal(t, flavor, FlavorCloud)
+	flavor, err = ParseFlavor("")
+	assert.NilError(t, err)
+	assert.Equal(t, flavor, FlavorAuto)
+	_, err = ParseFlavor("v4")
+	assert.Error(t, err, "unknown API flavor: v4, expected one of: auto, server, cloud")
+}
@@ -0,0 +1,46 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"net/url"
+	"strings"
+)
+
+// IssueExpands lists expansions of issue resource accepted by IssueOptions
+var IssueExpands = []string{"changelog", "renderedFields", "names", "schema", "transitions", "operations", "editmeta", "versionedRepresentations"}
+
+// IssueOptions type selects fields and expansions of fetched issues.
+// Empty Fields fetches all fields, field names can be prefixed with minus to skip them, e.g. "*all", "-comment"
+type IssueOptions struct {
+	Fields []string
+	Expand []string
+}
+
+// query method returns query parameters of options. Fields are set to defaultFields when none are selected
+func (o IssueOptions) query(defaultFields ...string) url.Values {
+	query := url.Values{}
+	fields := o.Fields
+	if len(fields) == 0 {
+		fields = defaultFields
+	}
+	if len(fields) > 0 {
+		query.Set("fields", strings.Join(fields, ","))
+	}
+	if len(o.Expand) > 0 {
+		query.Set("expand", strings.Join(o.Expand, ","))
+	}
+	return query
+}
@@ -0,0 +1,259 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"sort"
+	"strings"
+)
+
+// DefaultSubtaskType is issue type of sub-tasks of template which don't set it
+var DefaultSubtaskType = "Sub-task"
+
+// IssueTemplate type represents issue created from YAML or JSON file or CSV row. Fields are given by name or id,
+// their text, number or list values are encoded like in FieldValue and objects are sent as they are
+type IssueTemplate struct {
+	Project     string                 `yaml:"project,omitempty" json:"project,omitempty"`
+	Type        string                 `yaml:"type,omitempty" json:"type,omitempty"`
+	Summary     string                 `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Description string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	Parent      string                 `yaml:"parent,omitempty" json:"parent,omitempty"`
+	Fields      map[string]interface{} `yaml:"fields,omitempty" json:"fields,omitempty"`
+	Links       []LinkTemplate         `yaml:"links,omitempty" json:"links,omitempty"`
+	Subtasks    []IssueTemplate        `yaml:"subtasks,omitempty" json:"subtasks,omitempty"`
+}
+
+// LinkTemplate type represents link of created issue. Created issue relates to Outward issue with outward
+// description of link type, e.g. created issue blocks Outward, and Inward issue relates to created issue,
+// e.g. Inward blocks created issue
+type LinkTemplate struct {
+	Type    string `yaml:"type" json:"type"`
+	Outward string `yaml:"outward,omitempty" json:"outward,omitempty"`
+	Inward  string `yaml:"inward,omitempty" json:"inward,omitempty"`
+}
+
+// TemplateResult type represents issue created from template. Err is set when issue or any of its links
+// was not created, issue key is set when issue itself was created
+type TemplateResult struct {
+	Summary string       `json:"summary"`
+	Parent  string       `json:"parent,omitempty"`
+	Issue   models.Issue `json:"issue"`
+	Err     error        `json:"-"`
+}
+
+// Set method sets template field given by name or id. Project, issue type, summary, description and parent
+// are set in template, other fields in Fields
+func (t *IssueTemplate) Set(name string, value string) {
+	switch strings.ToLower(name) {
+	case "project":
+		t.Project = value
+	case "type", "issuetype", "issue type":
+		t.Type = value
+	case "summary":
+		t.Summary = value
+	case "description":
+		t.Description = value
+	case "parent":
+		t.Parent = value
+	default:
+		if t.Fields == nil {
+			t.Fields = make(map[string]interface{})
+		}
+		t.Fields[name] = value
+	}
+}
+
+// Subtask method returns sub-task template of issue with given key. Sub-task inherits project of template
+// and has DefaultSubtaskType when it doesn't set type
+func (t IssueTemplate) Subtask(subtask IssueTemplate, parentKey string) IssueTemplate {
+	if subtask.Project == "" {
+		subtask.Project = t.Project
+	}
+	if subtask.Type == "" {
+		subtask.Type = DefaultSubtaskType
+	}
+	subtask.Parent = parentKey
+	return subtask
+}
+
+// TemplateFields method returns fields of issue created from template
+func (c *Client) TemplateFields(ctx context.Context, t IssueTemplate) (models.Fields, error) {
+	fields := models.Fields{Summary: t.Summary, Description: models.RichText(t.Description)}
+	if t.Project != "" {
+		fields.Project = &models.Project{Key: t.Project}
+	}
+	if t.Type != "" {
+		fields.IssueType = &models.IssueType{Name: t.Type}
+	}
+	if t.Parent != "" {
+		if err := fields.Set("parent", map[string]string{"key": t.Parent}); err != nil {
+			return fields, err
+		}
+	}
+	names := make([]string, 0, len(t.Fields))
+	for name := range t.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := normalizeValue(t.Fields[name])
+		if value == nil || value == "" {
+			continue
+		}
+		if strings.EqualFold(name, "parent") {
+			if err := fields.Set("parent", map[string]string{"key": fmt.Sprint(value)}); err != nil {
+				return fields, err
+			}
+			continue
+		}
+		field, err := c.GetField(ctx, name)
+		if err != nil {
+			return fields, err
+		}
+		if text, ok := templateText(value); ok {
+			value, err = c.FieldValue(ctx, field, text)
+			if err != nil {
+				return fields, err
+			}
+		}
+		if err := fields.Set(field.Id, value); err != nil {
+			return fields, err
+		}
+	}
+	return fields, nil
+}
+
+// normalizeValue converts maps decoded from YAML to maps with string keys, so value can be encoded as JSON
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = normalizeValue(item)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = normalizeValue(item)
+		}
+		return m
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = normalizeValue(item)
+		}
+		return items
+	}
+	return value
+}
+
+// templateText returns text of scalar value or comma separated text of list of scalars.
+// Objects and lists of objects have no text
+func templateText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return "", false
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			text, ok := templateText(item)
+			if !ok {
+				return "", false
+			}
+			items = append(items, text)
+		}
+		return strings.Join(items, ","), true
+	}
+	return fmt.Sprint(value), true
+}
+
+// CreateFromTemplates method creates issues from templates with bulk requests, then their sub-tasks and links.
+// Results are in order of templates, every issue is followed by its sub-tasks
+func (c *Client) CreateFromTemplates(ctx context.Context, templates []IssueTemplate) []TemplateResult {
+	results := c.createTemplates(ctx, templates, nil)
+	parents := make([]*TemplateResult, 0)
+	subtasks := make([]IssueTemplate, 0)
+	for i, t := range templates {
+		for _, s := range t.Subtasks {
+			parents = append(parents, &results[i])
+			subtasks = append(subtasks, t.Subtask(s, results[i].Issue.Key))
+		}
+	}
+	subtaskResults := c.createTemplates(ctx, subtasks, parents)
+	ordered := make([]TemplateResult, 0, len(results)+len(subtaskResults))
+	next := 0
+	for i := range results {
+		ordered = append(ordered, results[i])
+		for ; next < len(subtaskResults) && parents[next] == &results[i]; next++ {
+			ordered = append(ordered, subtaskResults[next])
+		}
+	}
+	return ordered
+}
+
+// createTemplates method creates issues from templates and their links. Templates with given parents
+// are not created when parent was not created
+func (c *Client) createTemplates(ctx context.Context, templates []IssueTemplate, parents []*TemplateResult) []TemplateResult {
+	results := make([]TemplateResult, len(templates))
+	fieldsList := make([]models.Fields, 0, len(templates))
+	positions := make([]int, 0, len(templates))
+	for i, t := range templates {
+		results[i].Summary = t.Summary
+		results[i].Parent = t.Parent
+		if parents != nil && parents[i].Issue.Key == "" {
+			results[i].Err = errors.New("parent issue was not created")
+			continue
+		}
+		fields, err := c.TemplateFields(ctx, t)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		fieldsList = append(fieldsList, fields)
+		positions = append(positions, i)
+	}
+	issues, failures := c.CreateIssues(ctx, fieldsList)
+	for j, i := range positions {
+		results[i].Issue = issues[j]
+		if err, ok := failures[j]; ok {
+			results[i].Err = err
+			continue
+		}
+		results[i].Err = c.linkTemplate(ctx, issues[j].Key, templates[i].Links)
+	}
+	return results
+}
+
+// linkTemplate method creates links of issue created from template
+func (c *Client) linkTemplate(ctx context.Context, issueKey string, links []LinkTemplate) error {
+	for _, l := range links {
+		var err error
+		if l.Outward != "" {
+			err = c.LinkIssues(ctx, l.Type, issueKey, l.Outward)
+		}
+		if err == nil && l.Inward != "" {
+			err = c.LinkIssues(ctx, l.Type, l.Inward, issueKey)
+		}
+		if err != nil {
+			return fmt.Errorf("issue %s created, but link %s was not: %w", issueKey, l.Type, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const releaseTemplate = `
+- project: TEST
+  type: Story
+  summary: Release 1.0.0
+  fields:
+    Story Points: 5
+    labels: [release, ci]
+    customfield_10003: {id: "10100"}
+  links:
+    - type: Blocks
+      outward: TEST-1
+  subtasks:
+    - summary: Deploy
+    - summary: Announce
+      type: Task
+- project: TEST
+  type: Task
+  summary: Retrospective
+`
+
+func TestTemplateFields(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/field",
+		httpmock.NewStringResponder(200, readResponse("./responses/field.json")))
+	templates := make([]IssueTemplate, 0)
+	assert.NilError(t, yaml.Unmarshal([]byte(releaseTemplate), &templates))
+
+	fields, err := c.TemplateFields(context.Background(), templates[0])
+	assert.NilError(t, err)
+	payload, err := json.Marshal(fields)
+	assert.NilError(t, err)
+	expected := `{"customfield_10002":5,"customfield_10003":{"id":"10100"},"issuetype":{"name":"Story"},"labels":["release","ci"],"project":{"key":"TEST"},"summary":"Release 1.0.0"}`
+	assert.Equal(t, string(payload), expected)
+}
+
+func TestCreateFromTemplates(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/field",
+		httpmock.NewStringResponder(200, readResponse("./responses/field.json")))
+	created := 0
+	httpmock.RegisterResponder("POST", "https://jira.example.com/rest/api/2/issue/bulk",
+		func(req *http.Request) (*http.Response, error) {
+			request := models.IssueBulkRequest{}
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &request); err != nil {
+				return nil, err
+			}
+			response := models.IssueBulkResponse{}
+			for range request.IssueUpdates {
+				created++
+				response.Issues = append(response.Issues, models.Issue{Key: "TEST-" + strings.Repeat("1", created+1)})
+			}
+			payload, _ := json.Marshal(response)
+			return httpmock.NewBytesResponse(201, payload), nil
+		})
+	links := 0
+	httpmock.RegisterResponder("POST", "https://jira.example.com/rest/api/2/issueLink",
+		func(req *http.Request) (*http.Response, error) {
+			links++
+			return httpmock.NewStringResponse(201, ""), nil
+		})
+	templates := make([]IssueTemplate, 0)
+	assert.NilError(t, yaml.Unmarshal([]byte(releaseTemplate), &templates))
+
+	results := c.CreateFromTemplates(context.Background(), templates)
+	assert.Equal(t, len(results), 4)
+	summaries := make([]string, 0)
+	for _, r := range results {
+		assert.NilError(t, r.Err)
+		summaries = append(summaries, r.Summary)
+	}
+	assert.DeepEqual(t, summaries, []string{"Release 1.0.0", "Deploy", "Announce", "Retrospective"})
+	assert.Equal(t, results[0].Issue.Key, "TEST-11")
+	assert.Equal(t, results[1].Parent, "TEST-11")
+	assert.Equal(t, results[3].Issue.Key, "TEST-111")
+	assert.Equal(t, httpmock.GetCallCountInfo()["POST https://jira.example.com/rest/api/2/issue/bulk"], 2)
+	assert.Equal(t, links, 1)
+}
+
+func TestIssueTemplateSet(t *testing.T) {
+	template := IssueTemplate{}
+	template.Set("Project", "TEST")
+	template.Set("Issue Type", "Bug")
+	template.Set("summary", "Crash")
+	template.Set("Story Points", "3")
+	assert.DeepEqual(t, template, IssueTemplate{Project: "TEST", Type: "Bug", Summary: "Crash", Fields: map[string]interface{}{"Story Points": "3"}})
+	subtask := template.Subtask(IssueTemplate{Summary: "Fix"}, "TEST-1")
+	assert.DeepEqual(t, subtask, IssueTemplate{Project: "TEST", Type: DefaultSubtaskType, Summary: "Fix", Parent: "TEST-1"})
+}
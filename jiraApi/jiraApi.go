@@ -15,89 +15,33 @@
 package jiraApi
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/antchfx/htmlquery"
 	"github.com/sirupsen/logrus"
 	"github.com/sotomskir/jira-cli/graph"
 	"github.com/sotomskir/jira-cli/jiraApi/models"
 	"github.com/spf13/viper"
 	"gopkg.in/resty.v1"
+	"net/http"
+	"net/url"
 	"strings"
 	"sync"
-	"time"
 )
 
-var (
-	url string
-)
-
-// Initialize method is used to initialize API client
-func Initialize(serverUrl string, username string, password string) {
-	resty.SetHostURL(serverUrl)
-	resty.SetTimeout(1 * time.Minute)
-	s := strings.Split(serverUrl, "//")
-	if len(s) > 1 {
-		s[1] = username + ":" + password + "@" + s[1]
-		url = strings.Join(s, "//")
-	}
-	resty.SetBasicAuth(username, password)
-	// Headers for all request
-	resty.SetHeader("Accept", "application/json")
-	resty.SetHeaders(map[string]string{
-		"Content-Type":      "application/json",
-		"User-Agent":        "jira-cli",
-		"X-Atlassian-Token": "no-check",
-	})
-}
-
-func execute(method string, endpoint string, payload interface{}, response interface{}, queryString string, headers map[string]string) (code int, error error) {
-	r := resty.R()
-	if payload != nil {
-		r.SetBody(payload)
-		p, _ := json.Marshal(payload)
-		logrus.Tracef("Request payload: %s\n", string(p))
-	}
-
-	if queryString != "" {
-		r.SetQueryString(queryString)
-	}
-
-	if headers != nil {
-		r.SetHeaders(headers)
-	}
-
-	res, err := r.Execute(method, endpoint)
-	logrus.Debugf("%s: %s Response: %d %s\n", method, endpoint, res.StatusCode(), string(res.Body()))
-	if err != nil {
-		logrus.Errorln(err)
-		return 1, err
-	}
-
-	if res.StatusCode() >= 400 {
-		return res.StatusCode(), errors.New(fmt.Sprintf("http error: %d", res.StatusCode()))
-	}
-
-	if res.StatusCode() == 204 {
-		return 204, nil
-	}
-
-	jsonErr := json.Unmarshal(res.Body(), response)
-
-	if jsonErr != nil {
-		logrus.Errorf("StatusCode: %d\nServer responded with invalid JSON: %s\nResponse: %s\n", res.StatusCode(), jsonErr, string(res.Body()))
-		return 1, errors.New("unmarshalling error")
-	}
-
-	return 0, nil
-}
-
-// GetVersions method returns JIRA versions of given project
-func GetVersions(projectKey string) []models.Version {
+// GetVersions method returns JIRA versions of given project. Limit caps number of versions, zero means no limit
+func (c *Client) GetVersions(ctx context.Context, projectKey string, limit int) ([]models.Version, error) {
 	versions := make([]models.Version, 0)
-	execute(resty.MethodGet, fmt.Sprintf("rest/api/2/project/%s/versions", projectKey), nil, &versions, "", nil)
-	return versions
+	it := c.NewPageIterator(ctx, fmt.Sprintf("rest/api/2/project/%s/version", projectKey), nil, "values", limit)
+	for it.Next() {
+		page := make([]models.Version, 0)
+		if err := it.Decode(&page); err != nil {
+			return versions, err
+		}
+		versions = append(versions, page...)
+	}
+	return versions, it.Err()
 }
 
 func find(vs []models.Version, name string) (models.Version, error) {
@@ -110,14 +54,21 @@ func find(vs []models.Version, name string) (models.Version, error) {
 }
 
 // GetVersion method returns JIRA version details
-func GetVersion(projectKey string, version string) (models.Version, error) {
-	versions := GetVersions(projectKey)
+func (c *Client) GetVersion(ctx context.Context, projectKey string, version string) (models.Version, error) {
+	versions, err := c.GetVersions(ctx, projectKey, 0)
+	if err != nil {
+		return models.Version{}, err
+	}
 	return find(versions, version)
 }
 
 // CreateVersion creates new version in specified project
-func CreateVersion(projectKey string, version string) (createdVersion models.Version, created bool, err error) {
-	existingVersion, err := GetVersion(projectKey, version)
+func (c *Client) CreateVersion(ctx context.Context, projectKey string, version string) (createdVersion models.Version, created bool, err error) {
+	versions, err := c.GetVersions(ctx, projectKey, 0)
+	if err != nil {
+		return models.Version{}, false, err
+	}
+	existingVersion, err := find(versions, version)
 	if err == nil {
 		logrus.Infof("Version %s already exists in project %s\n", version, projectKey)
 		return existingVersion, false, nil
@@ -126,7 +77,8 @@ func CreateVersion(projectKey string, version string) (createdVersion models.Ver
 	payload.Name = version
 	payload.Project = projectKey
 	response := models.Version{}
-	_, err = execute(resty.MethodPost, "rest/api/2/version", payload, &response, "", nil)
+	_, err = c.execute(ctx, resty.MethodPost, "rest/api/2/version", payload, &response, "", nil)
+	c.invalidateCached(CacheVersions, fmt.Sprintf("rest/api/2/project/%s/version", projectKey))
 	if err != nil {
 		logrus.Errorf("Error executing rest/api/2/version: %s\n", err)
 		return response, false, err
@@ -135,32 +87,53 @@ func CreateVersion(projectKey string, version string) (createdVersion models.Ver
 	return response, true, nil
 }
 
-func updateVersion(versionId string, payload models.Version) models.Version {
+func (c *Client) updateVersion(ctx context.Context, versionId string, payload models.Version) (models.Version, error) {
 	response := models.Version{}
-	execute(resty.MethodPut, fmt.Sprintf("rest/api/2/version/%s", versionId), payload, &response, "", nil)
-	return response
+	_, err := c.execute(ctx, resty.MethodPut, fmt.Sprintf("rest/api/2/version/%s", versionId), payload, &response, "", nil)
+	return response, err
 }
 
 // ReleaseVersion method changes project status to "released"
-func ReleaseVersion(projectKey string, version string) {
-	versionFromServer, _ := GetVersion(projectKey, version)
+func (c *Client) ReleaseVersion(ctx context.Context, projectKey string, version string) error {
+	versionFromServer, err := c.GetVersion(ctx, projectKey, version)
+	if err != nil {
+		return err
+	}
 	payload := models.Version{}
 	payload.Released = true
-	updateVersion(versionFromServer.Id, payload)
+	_, err = c.updateVersion(ctx, versionFromServer.Id, payload)
+	c.invalidateCached(CacheVersions, fmt.Sprintf("rest/api/2/project/%s/version", projectKey))
+	return err
 }
 
 // GetProject method returns project details
-func GetProject(projectKey string) models.Project {
+func (c *Client) GetProject(ctx context.Context, projectKey string) (models.Project, error) {
 	project := models.Project{}
-	execute(resty.MethodGet, fmt.Sprintf("rest/api/2/project/%s", projectKey), nil, &project, "", nil)
-	return project
+	_, err := c.execute(ctx, resty.MethodGet, fmt.Sprintf("rest/api/2/project/%s", projectKey), nil, &project, "", nil)
+	return project, err
 }
 
-// GetProjects method list all projects
-func GetProjects() []models.Project {
+// GetProjects method list all projects. Limit caps number of projects, zero means no limit.
+// Servers without paginated project search are asked for full project list
+func (c *Client) GetProjects(ctx context.Context, limit int) ([]models.Project, error) {
 	projects := make([]models.Project, 0)
-	execute(resty.MethodGet, "rest/api/2/project", nil, &projects, "", nil)
-	return projects
+	it := c.NewPageIterator(ctx, "rest/api/2/project/search", nil, "values", limit)
+	for it.Next() {
+		page := make([]models.Project, 0)
+		if err := it.Decode(&page); err != nil {
+			return projects, err
+		}
+		projects = append(projects, page...)
+	}
+	var apiError *APIError
+	if !errors.As(it.Err(), &apiError) || apiError.StatusCode != http.StatusNotFound {
+		return projects, it.Err()
+	}
+	_, err := c.execute(ctx, resty.MethodGet, "rest/api/2/project", nil, &projects, "", nil)
+	if limit > 0 && len(projects) > limit {
+		projects = projects[:limit]
+	}
+	return projects, err
 }
 
 func mapVersionName(versions []models.Version) []string {
@@ -171,147 +144,369 @@ func mapVersionName(versions []models.Version) []string {
 	return result
 }
 
-// SetFixVersion method sets fix version of issue. When version is already set it won't be modified
-func SetFixVersion(issueKey string, version string) error {
-	response, err := GetIssue(issueKey)
+// SetFixVersion method sets fix version of issue. When version is already set it won't be modified and ErrFixVersionSet is returned
+func (c *Client) SetFixVersion(ctx context.Context, issueKey string, version string) error {
+	response, err := c.GetIssue(ctx, issueKey)
 	if err != nil {
 		return err
 	}
 	if len(response.Fields.FixVersions) > 0 {
 		logrus.Warnf("Fix version is already set to: %#v\n", mapVersionName(response.Fields.FixVersions))
-		return errors.New("fix version is already set")
+		return ErrFixVersionSet
 	}
-	_, err = execute(resty.MethodPut, fmt.Sprintf("rest/api/2/issue/%s", issueKey), fmt.Sprintf("{\"update\":{\"fixVersions\":[{\"set\":[{\"name\":\"%s\"}]}]}}", version), &response, "", nil)
-	if err != nil {
-		return err
-	}
-	return nil
+	update := models.IssueUpdate{}
+	update.Add("fixVersions", OperationSet, []map[string]string{{"name": version}})
+	return c.UpdateIssue(ctx, issueKey, update)
 }
 
-func CreateFixVersion(projectKey string, version string, createDeploymentIssue bool, summary string, description string, issueType string) error {
-	fixVersion, created, err := CreateVersion(projectKey, version)
+func (c *Client) CreateFixVersion(ctx context.Context, projectKey string, version string, createDeploymentIssue bool, summary string, description string, issueType string) error {
+	fixVersion, created, err := c.CreateVersion(ctx, projectKey, version)
 	if err != nil {
-		return errors.New(fmt.Sprintf("Cannot create version: %s in project: %s: %s", version, projectKey, err))
+		return fmt.Errorf("Cannot create version: %s in project: %s: %w", version, projectKey, err)
 	}
 	if created && createDeploymentIssue {
-		issue, err := CreateIssue(projectKey, summary, description, issueType, &fixVersion)
+		issue, err := c.CreateIssue(ctx, projectKey, summary, description, issueType, &fixVersion)
 		if err != nil && createDeploymentIssue {
-			return errors.New(fmt.Sprintf("Cannot create deployment issue: %s", err))
+			return fmt.Errorf("Cannot create deployment issue: %w", err)
 		} else {
-			logrus.Infoln("Deployment issue %s created", issue.Key)
+			logrus.Infof("Deployment issue %s created\n", issue.Key)
 		}
 	}
 	return nil
 }
 
 // GetIssue method returns issue details
-func GetIssue(issueKey string) (i models.Issue, error error) {
+func (c *Client) GetIssue(ctx context.Context, issueKey string) (i models.Issue, error error) {
+	return c.GetIssueWithOptions(ctx, issueKey, IssueOptions{})
+}
+
+// GetIssueWithOptions method returns issue with fields and expansions selected in options
+func (c *Client) GetIssueWithOptions(ctx context.Context, issueKey string, options IssueOptions) (models.Issue, error) {
 	issue := models.Issue{}
-	_, err := execute(resty.MethodGet, fmt.Sprintf("rest/api/2/issue/%s", issueKey), nil, &issue, "", nil)
+	_, err := c.execute(ctx, resty.MethodGet, fmt.Sprintf("rest/api/2/issue/%s", issueKey), nil, &issue, options.query().Encode(), nil)
 	if err != nil {
 		return issue, err
 	}
 	return issue, nil
 }
 
-// GetIssueWorkflow method returns issue details
-func GetIssueWorkflow(issueKey string) (*models.Workflow, error) {
-	workflowName, err := GetIssueWorkflowName(issueKey)
+// GetIssueWorkflow method returns workflow used by issue
+func (c *Client) GetIssueWorkflow(ctx context.Context, issueKey string) (*models.Workflow, error) {
+	issue, err := c.GetIssue(ctx, issueKey)
 	if err != nil {
 		return nil, err
 	}
+	return c.issueWorkflow(ctx, issue)
+}
+
+// GetIssueWorkflowName method returns name of workflow used by issue
+func (c *Client) GetIssueWorkflowName(ctx context.Context, issueKey string) (name string, error error) {
+	issue, err := c.GetIssue(ctx, issueKey)
+	if err != nil {
+		return "", err
+	}
+	if issue.Fields.Project == nil || issue.Fields.IssueType == nil {
+		return "", fmt.Errorf("can't find project and issue type of issue: %s", issueKey)
+	}
+	return c.GetWorkflowName(ctx, issue.Fields.Project.Key, issue.Fields.IssueType.Id)
+}
+
+// issueWorkflow method returns workflow used by project and issue type of fetched issue
+func (c *Client) issueWorkflow(ctx context.Context, issue models.Issue) (*models.Workflow, error) {
+	if issue.Fields.Project == nil || issue.Fields.IssueType == nil {
+		return nil, fmt.Errorf("can't find project and issue type of issue: %s", issue.Key)
+	}
+	return c.GetWorkflow(ctx, issue.Fields.Project.Key, issue.Fields.IssueType.Id)
+}
+
+// GetWorkflowScheme method returns workflow scheme assigned to project.
+// Servers without workflow scheme project resource are asked with project configuration resource
+func (c *Client) GetWorkflowScheme(ctx context.Context, projectKey string) (models.WorkflowScheme, error) {
+	project, err := c.GetProject(ctx, projectKey)
+	if err != nil {
+		return models.WorkflowScheme{}, err
+	}
+	associations := models.WorkflowSchemeProjectAssociations{}
+	_, err = c.execute(ctx, resty.MethodGet, "rest/api/2/workflowscheme/project", nil, &associations, "projectId="+url.QueryEscape(project.Id), nil)
+	if err == nil {
+		for _, a := range associations.Values {
+			for _, id := range a.ProjectIds {
+				if id == project.Id {
+					return a.WorkflowScheme, nil
+				}
+			}
+		}
+		return models.WorkflowScheme{}, fmt.Errorf("can't find workflow scheme of project: %s", projectKey)
+	}
+	var apiError *APIError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusNotFound {
+		return models.WorkflowScheme{}, err
+	}
+	scheme := models.ProjectWorkflowScheme{}
+	_, err = c.execute(ctx, resty.MethodGet, fmt.Sprintf("rest/projectconfig/latest/workflowscheme/%s", project.Key), nil, &scheme, "", nil)
+	if err != nil {
+		return models.WorkflowScheme{}, err
+	}
+	return scheme.WorkflowScheme(), nil
+}
+
+// GetWorkflowName method returns name of workflow used by issue type in project
+func (c *Client) GetWorkflowName(ctx context.Context, projectKey string, issueTypeId string) (string, error) {
+	scheme, err := c.GetWorkflowScheme(ctx, projectKey)
+	if err != nil {
+		return "", err
+	}
+	name := scheme.WorkflowName(issueTypeId)
+	if name == "" {
+		return "", fmt.Errorf("can't find workflow of issue type %s in project: %s", issueTypeId, projectKey)
+	}
+	return name, nil
+}
+
+// GetWorkflow method returns workflow used by issue type in project.
+// Workflow is fetched once per project and issue type, concurrent callers wait for the first request
+func (c *Client) GetWorkflow(ctx context.Context, projectKey string, issueTypeId string) (*models.Workflow, error) {
+	key := projectKey + "/" + issueTypeId
+	c.workflowsMu.Lock()
+	if c.workflows == nil {
+		c.workflows = make(map[string]*workflowCall)
+	}
+	if call, ok := c.workflows[key]; ok {
+		c.workflowsMu.Unlock()
+		select {
+		case <-call.done:
+			return call.workflow, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call := &workflowCall{done: make(chan struct{})}
+	c.workflows[key] = call
+	c.workflowsMu.Unlock()
+
+	call.workflow, call.err = c.fetchWorkflow(ctx, projectKey, issueTypeId)
+	if call.err != nil {
+		c.workflowsMu.Lock()
+		delete(c.workflows, key)
+		c.workflowsMu.Unlock()
+	}
+	close(call.done)
+	return call.workflow, call.err
+}
+
+// workflowCall type holds workflow fetched for project and issue type
+type workflowCall struct {
+	done     chan struct{}
+	workflow *models.Workflow
+	err      error
+}
+
+// fetchWorkflow returns workflow from workflow designer on JIRA Server and from workflow search on JIRA Cloud
+func (c *Client) fetchWorkflow(ctx context.Context, projectKey string, issueTypeId string) (*models.Workflow, error) {
+	workflowName, err := c.GetWorkflowName(ctx, projectKey, issueTypeId)
+	if err != nil {
+		return nil, err
+	}
+	flavor, err := c.Flavor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if flavor == FlavorCloud {
+		return c.fetchCloudWorkflow(ctx, workflowName)
+	}
 	w := models.Workflow{}
 	headers := make(map[string]string)
 	headers["X-Atlassian-Token"] = "no-check"
-	_, err = execute(resty.MethodGet, fmt.Sprintf("rest/workflowDesigner/latest/workflows?name=%s", workflowName), nil, &w, "", headers)
+	_, err = c.execute(ctx, resty.MethodGet, "rest/workflowDesigner/latest/workflows", nil, &w, "name="+url.QueryEscape(workflowName), headers)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	return &w, nil
 }
 
-// GetIssueWorkflow method returns issue details
-func GetIssueWorkflowName(issueKey string) (name string, error error) {
-	doc, err := htmlquery.LoadURL(fmt.Sprintf("%s/browse/%s", url, issueKey))
+// fetchCloudWorkflow returns workflow with given name from JIRA Cloud workflow search
+func (c *Client) fetchCloudWorkflow(ctx context.Context, workflowName string) (*models.Workflow, error) {
+	search := models.WorkflowSearch{}
+	query := url.Values{"workflowName": {workflowName}, "expand": {"statuses,transitions"}}
+	_, err := c.execute(ctx, resty.MethodGet, "rest/api/2/workflow/search", nil, &search, query.Encode(), nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	list := htmlquery.Find(doc, "//*[contains(@class,'jira-workflow-designer-link')]")
-	for _, e := range list {
-		for _, a := range e.Attr {
-			if a.Key == "href" {
-				return strings.Split(strings.Split(a.Val, "workflowName=")[1], "&")[0], nil
-			}
+	for _, cloudWorkflow := range search.Values {
+		if cloudWorkflow.Id.Name == workflowName {
+			w := cloudWorkflow.Workflow()
+			return &w, nil
 		}
 	}
-	return "", errors.New(fmt.Sprintf("can't find workflow name for issue: %s", issueKey))
+	return nil, fmt.Errorf("can't find workflow: %s", workflowName)
 }
 
-func GetIssues(issueKeys []string) []models.Issue {
-	var issues []models.Issue
-	var wg sync.WaitGroup
+// IssueChunkSize is number of issue keys fetched with single JQL search by GetIssues
+var IssueChunkSize = 50
 
-	for _, issueKey := range issueKeys {
+// GetIssues method returns issues in order of given keys. Keys are searched with JQL in chunks fetched in parallel,
+// issues missing from search result are fetched one by one. Keys which could not be fetched are returned with their errors
+func (c *Client) GetIssues(ctx context.Context, issueKeys []string) ([]models.Issue, map[string]error) {
+	return c.GetIssuesWithOptions(ctx, issueKeys, IssueOptions{})
+}
+
+// GetIssuesWithOptions method returns issues with fields and expansions selected in options. See GetIssues
+func (c *Client) GetIssuesWithOptions(ctx context.Context, issueKeys []string, options IssueOptions) ([]models.Issue, map[string]error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	found := make(map[string]models.Issue)
+	missing := make([]string, 0)
+	for start := 0; start < len(issueKeys); start += IssueChunkSize {
+		end := start + IssueChunkSize
+		if end > len(issueKeys) {
+			end = len(issueKeys)
+		}
 		wg.Add(1)
-		go func(issueKey string, issues *[]models.Issue) {
-			resp, err := GetIssue(issueKey)
+		go func(chunk []string) {
+			defer wg.Done()
+			issues, err := c.searchIssueKeys(ctx, chunk, options)
 			if err != nil {
-				logrus.Errorf("Selected issue %s does not exist.", issueKey)
-			} else {
-				*issues = append(*issues, resp)
+				logrus.Debugf("Cannot search issues %v, fetching them one by one: %s\n", chunk, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, issue := range issues {
+				found[strings.ToUpper(issue.Key)] = issue
 			}
-			wg.Done()
-		}(issueKey, &issues)
-		wg.Wait()
+			for _, key := range chunk {
+				if _, ok := found[strings.ToUpper(key)]; !ok {
+					missing = append(missing, key)
+				}
+			}
+		}(issueKeys[start:end])
+	}
+	wg.Wait()
+
+	failures := make(map[string]error)
+	c.ForEach(ctx, missing, func(key string) {
+		issue, err := c.GetIssueWithOptions(ctx, key, options)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failures[key] = err
+			return
+		}
+		found[strings.ToUpper(key)] = issue
+	})
+
+	issues := make([]models.Issue, 0, len(issueKeys))
+	for _, key := range issueKeys {
+		if issue, ok := found[strings.ToUpper(key)]; ok {
+			issues = append(issues, issue)
+		} else if _, ok := failures[key]; !ok && ctx.Err() != nil {
+			failures[key] = ctx.Err()
+		}
 	}
+	return issues, failures
+}
 
-	return issues
+// searchIssueKeys method returns issues with given keys found with JQL search. Keys which don't exist are skipped by JIRA Cloud,
+// JIRA Server fails whole search
+func (c *Client) searchIssueKeys(ctx context.Context, issueKeys []string, options IssueOptions) ([]models.Issue, error) {
+	quoted := make([]string, 0, len(issueKeys))
+	for _, key := range issueKeys {
+		quoted = append(quoted, QuoteJQL(key))
+	}
+	query := options.query("*all")
+	query.Set("jql", fmt.Sprintf("key in (%s)", strings.Join(quoted, ",")))
+	issues, err := c.searchIssues(ctx, query, len(issueKeys))
+	return issues.Issues, err
 }
 
-func GetIssuesInVersions(projectKey string, version string, issueTypes string) (issuesInVersionList models.IssueList, error error) {
-	response := models.IssueList{}
-	_, err := execute(resty.MethodGet, fmt.Sprintf("rest/api/2/search"), nil, &response, fmt.Sprintf("jql=project in (%s) and fixVersion in (%s) and issueType in (%s)&fields=key,summary", projectKey, version, issueTypes), nil)
+// GetIssuesInVersions method returns key and summary of issues of comma separated types in version of project.
+// Arguments are quoted in JQL. Limit caps number of issues, zero means no limit
+func (c *Client) GetIssuesInVersions(ctx context.Context, projectKey string, version string, issueTypes string, limit int) (issuesInVersionList models.IssueList, error error) {
+	jql := SearchQuery{Project: []string{projectKey}, Version: []string{version}, Type: splitItems(issueTypes)}.Build()
+	return c.SearchIssues(ctx, jql, []string{"key", "summary"}, limit)
+}
 
-	return response, err
+// SearchIssues method returns issues matching JQL query with given fields. Limit caps number of issues, zero means no limit
+func (c *Client) SearchIssues(ctx context.Context, jql string, fields []string, limit int) (models.IssueList, error) {
+	query := IssueOptions{Fields: fields}.query()
+	query.Set("jql", jql)
+	return c.searchIssues(ctx, query, limit)
+}
+
+func (c *Client) searchIssues(ctx context.Context, query url.Values, limit int) (models.IssueList, error) {
+	response := models.IssueList{Issues: make([]models.Issue, 0)}
+	it := c.NewPageIterator(ctx, "rest/api/2/search", query, "issues", limit)
+	for it.Next() {
+		page := make([]models.Issue, 0)
+		if err := it.Decode(&page); err != nil {
+			return response, err
+		}
+		response.Issues = append(response.Issues, page...)
+	}
+	response.Total = it.Total()
+	return response, it.Err()
 }
 
 // Worklog method add worklog to issue
-func AddWorklog(key string, min uint64, com string, date string, time string) (models.WorklogResp, error) {
+func (c *Client) AddWorklog(ctx context.Context, key string, min uint64, com string, date string, time string) (models.WorklogResp, error) {
 	payload, werr := models.InitilizeWorklogAdd(com, min, date, time)
 	wr := models.WorklogResp{}
 	if werr != nil {
 		return wr, werr
 	}
+	flavor, err := c.Flavor(ctx)
+	if err != nil {
+		return wr, err
+	}
+	var body interface{} = payload
+	if flavor == FlavorCloud {
+		body = struct {
+			models.WorklogAdd
+			Comment *models.Document `json:"comment"`
+		}{payload, models.NewDocument(payload.Comment)}
+	}
 	logrus.Infof("Attempting to add %d[sec] for issue %s for date %s.", payload.TimeSpentSeconds, key, payload.Started)
-	_, err := execute(resty.MethodPost, fmt.Sprintf("rest/api/2/issue/%s/worklog", key), payload, &wr, "", nil)
+	_, err = c.execute(ctx, resty.MethodPost, fmt.Sprintf("rest/api/2/issue/%s/worklog", key), body, &wr, "", nil)
 	if err == nil && len(wr.Id) > 0 {
 		logrus.Infof("Successfully added %d[sec] to issue %s.", payload.TimeSpentSeconds, key)
 		return wr, nil
-	} else {
-		msg := fmt.Sprintf("There was an error adding your time to issue %s. Details: %v", key, err)
-		logrus.Error(msg)
-		return wr, errors.New(msg)
 	}
+	if err == nil {
+		err = errors.New("server did not return worklog id")
+	}
+	return wr, fmt.Errorf("There was an error adding your time to issue %s. Details: %w", key, err)
 }
 
 //ListWorklog for specified JIRa issue
-func ListWorklog(key string) (worklogs models.WorklogList, error error) {
+func (c *Client) ListWorklog(ctx context.Context, key string, limit int) (worklogs models.WorklogList, error error) {
 	logrus.Infof("Attempting to list worklogs for issue %s.", key)
-	response := models.WorklogList{}
-	_, err := execute(resty.MethodGet, fmt.Sprintf("rest/api/2/issue/%s/worklog", key), nil, &response, "", nil)
-	return response, err
+	response := models.WorklogList{Worklogs: make([]models.WorklogResp, 0)}
+	it := c.NewPageIterator(ctx, fmt.Sprintf("rest/api/2/issue/%s/worklog", key), nil, "worklogs", limit)
+	for it.Next() {
+		page := make([]models.WorklogResp, 0)
+		if err := it.Decode(&page); err != nil {
+			return response, err
+		}
+		response.Worklogs = append(response.Worklogs, page...)
+	}
+	response.Total = it.Total()
+	return response, it.Err()
 }
 
 //Delete specified worklog (id) from JIRA issue (key)
-func DeleteWorklog(key string, id string) (status int, error error) {
+func (c *Client) DeleteWorklog(ctx context.Context, key string, id string) (status int, error error) {
 	endpoint := fmt.Sprintf("rest/api/2/issue/%s/worklog/%s", key, id)
-	res, err := execute(resty.MethodDelete, endpoint, nil, nil, "", nil)
+	res, err := c.execute(ctx, resty.MethodDelete, endpoint, nil, nil, "", nil)
 	return res, err
 }
 
-//DeleteWorklogForUser get worklogs form given issue, filter for given user and delete all worklogs
-func DeleteWorklogForUser(user string, key string) (sumOk int, sumError int, error error) {
-	resp, err := ListWorklog(key)
+//DeleteWorklogForUser get worklogs form given issue, filter for given user and delete all worklogs.
+//User is identified by name on JIRA Server and by account id on JIRA Cloud
+func (c *Client) DeleteWorklogForUser(ctx context.Context, user string, key string) (sumOk int, sumError int, error error) {
+	flavor, err := c.Flavor(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	resp, err := c.ListWorklog(ctx, key, 0)
 	sumOk = 0
 	sumError = 0
 
@@ -320,9 +515,13 @@ func DeleteWorklogForUser(user string, key string) (sumOk int, sumError int, err
 	}
 
 	for _, p := range resp.Worklogs {
-		logrus.Infof("author: %s, challange: %s", p.Author.Name, user)
-		if p.Author.Name == user {
-			_, e := DeleteWorklog(key, p.Id)
+		if ctx.Err() != nil {
+			return sumOk, sumError, ctx.Err()
+		}
+		author := userId(flavor, p.Author)
+		logrus.Infof("author: %s, challange: %s", author, user)
+		if author == user {
+			_, e := c.DeleteWorklog(ctx, key, p.Id)
 			if e != nil {
 				logrus.Errorf("There was an error while deleting worklog %s for issue %s.", p.Id, key)
 				sumError++
@@ -336,42 +535,59 @@ func DeleteWorklogForUser(user string, key string) (sumOk int, sumError int, err
 	return sumOk, sumError, err
 }
 
-// TransitionIssue method executes issue transition
-func TransitionIssue(workflowPath string, issueKey string, targetStatus string, excludeStatus string) (status int, error error) {
-	//transitionMap, err := ReadWorkflow(workflowPath)
-	issue, err := GetIssue(issueKey)
-	w, err := GetIssueWorkflow(issueKey)
+// maxTransitions limits number of transitions executed by TransitionIssue
+const maxTransitions = 20
+
+// TransitionIssue method executes transitions moving issue to target status along shortest path in issue workflow.
+// Workflow errors are returned as TransitionError. Issue in excluded status is not transitioned and ErrExcludedStatus is returned
+func (c *Client) TransitionIssue(ctx context.Context, workflowPath string, issueKey string, targetStatus string, excludeStatus string) (int, error) {
+	issue, err := c.GetIssue(ctx, issueKey)
 	if err != nil {
 		return 1, err
 	}
-	transitionMap := BuildWorkflow(w, issue.Fields.Status.Name, targetStatus)
+	w, err := c.issueWorkflow(ctx, issue)
+	if err != nil {
+		return 1, err
+	}
+	transitionError := func(currentStatus string, err error) error {
+		return &TransitionError{IssueKey: issueKey, Status: currentStatus, Target: targetStatus, Err: err}
+	}
+	transitionMap, err := BuildWorkflow(w, issue.Fields.Status.Name, targetStatus)
+	if err != nil {
+		return 1, transitionError(issue.Fields.Status.Name, err)
+	}
 
-	for i := 0; i < 20; i++ {
-		issue, err := GetIssue(issueKey)
+	for i := 0; ; i++ {
+		issue, err := c.GetIssue(ctx, issueKey)
 		if err != nil {
 			return 1, err
 		}
 		if i == 0 && strings.ToLower(issue.Fields.Status.Name) == strings.ToLower(excludeStatus) {
-			logrus.Infof("skipped issue with excluded status: '%s'\n", issueKey)
-			return 0, nil
+			return 0, fmt.Errorf("%w: '%s'", ErrExcludedStatus, issue.Fields.Status.Name)
 		}
 		currentStatus := strings.ToLower(issue.Fields.Status.Name)
 		logrus.Infof("%s: current status: '%s', target status: '%s'\n", issueKey, currentStatus, targetStatus)
-		if currentStatus == targetStatus {
+		if currentStatus == strings.ToLower(targetStatus) {
 			break
 		}
+		if i == maxTransitions {
+			return 1, transitionError(currentStatus, fmt.Errorf("%w: status not reached after %d transitions", ErrNoPath, maxTransitions))
+		}
 		transitionName, err := transitionMap.GetOrDefault(currentStatus, targetStatus)
 		if err != nil {
-			panic(err)
+			return 1, transitionError(currentStatus, err)
+		}
+		transition, err := c.GetTransitionByName(ctx, issueKey, transitionName)
+		if errors.Is(err, ErrTransitionNotAvailable) {
+			return 1, transitionError(currentStatus, err)
 		}
-		transition, err := GetTransitionByName(issueKey, transitionName)
 		if err != nil {
 			return 1, err
 		}
 		payload := models.Transitions{}
 		payload.Transition = transition
 		logrus.Infof("%s: executing transition: '%s'\n", issueKey, transition.Name)
-		status, err := execute(resty.MethodPost, fmt.Sprintf("rest/api/2/issue/%s/transitions", issueKey), payload, nil, "", nil)
+		status, err := c.execute(ctx, resty.MethodPost, fmt.Sprintf("rest/api/2/issue/%s/transitions", issueKey), payload, nil, "", nil)
 		if err != nil {
 			logrus.Errorf("%s: executing transition: '%s'\n", issueKey, transition.Name)
 			return status, err
@@ -380,12 +596,23 @@ func TransitionIssue(workflowPath string, issueKey string, targetStatus string,
 	return 0, nil
 }
 
-func BuildWorkflow(workflow *models.Workflow, sourceStatus string, targetStatus string) *WorkflowTransitionsMap {
+// BuildWorkflow method returns transitions on shortest path between statuses of workflow.
+// It returns ErrUnknownStatus when workflow has no such status and ErrNoPath when target status can't be reached
+func BuildWorkflow(workflow *models.Workflow, sourceStatus string, targetStatus string) (*WorkflowTransitionsMap, error) {
 	workflowGraph := graph.NewFromWorkflow(workflow)
-	fromId := workflowGraph.FindVertexByName(sourceStatus).Id
-	toId := workflowGraph.FindVertexByName(targetStatus).Id
+	from, err := workflowGraph.FindVertexByName(sourceStatus)
+	if err != nil {
+		return nil, err
+	}
+	to, err := workflowGraph.FindVertexByName(targetStatus)
+	if err != nil {
+		return nil, err
+	}
 	transitionsMap := WorkflowTransitionsMap{Workflow: map[string]interface{}{}}
-	path := workflowGraph.FindPath(fromId, toId)
+	path, err := workflowGraph.FindPath(from.Id, to.Id)
+	if err != nil {
+		return nil, err
+	}
 	for path.Len() > 0 {
 		element := path.Front()
 		path.Remove(element)
@@ -398,62 +625,105 @@ func BuildWorkflow(workflow *models.Workflow, sourceStatus string, targetStatus
 			transitionsMap.Workflow[strings.ToLower(currentStatus.Name)] = subMap
 		}
 	}
-	return &transitionsMap
+	return &transitionsMap, nil
 }
 
 // GetTransitionByName method returns transition details from issue
-func GetTransitionByName(issueKey string, transitionName string) (models.Transition, error) {
-	transitions := GetTransitions(issueKey)
+func (c *Client) GetTransitionByName(ctx context.Context, issueKey string, transitionName string) (models.Transition, error) {
+	transitions, err := c.GetTransitions(ctx, issueKey)
+	if err != nil {
+		return models.Transition{}, err
+	}
 	for _, transition := range transitions {
 		if strings.ToLower(transition.Name) == strings.ToLower(transitionName) {
 			return transition, nil
 		}
 	}
-	return models.Transition{}, errors.New(fmt.Sprintf("transition '%s' is not found in transitions of issue: %s\n", transitionName, issueKey))
+	return models.Transition{}, fmt.Errorf("%w: '%s' is not found in transitions of issue: %s", ErrTransitionNotAvailable, transitionName, issueKey)
 }
 
 // GetTransitions method returns available transitions for issue
-func GetTransitions(issueKey string) []models.Transition {
+func (c *Client) GetTransitions(ctx context.Context, issueKey string) ([]models.Transition, error) {
 	transitions := models.Transitions{}
-	execute(resty.MethodGet, fmt.Sprintf("rest/api/2/issue/%s/transitions", issueKey), nil, &transitions, "", nil)
-	return transitions.Transitions
+	_, err := c.execute(ctx, resty.MethodGet, fmt.Sprintf("rest/api/2/issue/%s/transitions", issueKey), nil, &transitions, "", nil)
+	return transitions.Transitions, err
 }
 
-// TestTransitions method run through all transitions to test Workflow definition
-func TestTransitions(workflowPath string, issueKey string) error {
-	_, err := ReadWorkflow(workflowPath)
+// TestTransitions method run through all transitions to test Workflow definition.
+// Failed transitions are logged, error is returned when any transition failed
+func (c *Client) TestTransitions(ctx context.Context, workflowPath string, issueKey string) error {
+	_, err := c.ReadWorkflow(ctx, workflowPath)
 	if err != nil {
 		return err
 	}
+	failed := 0
+	transition := func(status string) {
+		if _, err := c.TransitionIssue(ctx, workflowPath, issueKey, status, ""); err != nil {
+			logrus.Errorln(err)
+			failed++
+		}
+	}
 	workflow := viper.GetStringMap("Workflow")
 	for fromState := range workflow {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		logrus.Infof("\tTesting transitions from state: '%s'\n", fromState)
-		TransitionIssue(workflowPath, issueKey, fromState, "")
+		transition(fromState)
 		for toState := range workflow {
 			logrus.Infof("\tto state: '%s'\n", toState)
-			TransitionIssue(workflowPath, issueKey, toState, "")
+			transition(toState)
 		}
 	}
+	if failed > 0 {
+		return fmt.Errorf("%d transitions failed", failed)
+	}
 	return nil
 }
 
-func CreateIssue(projectKey string, summary string, description string, issueType string, version *models.Version) (models.Issue, error) {
+func (c *Client) CreateIssue(ctx context.Context, projectKey string, summary string, description string, issueType string, version *models.Version) (models.Issue, error) {
 	versions := make([]models.Version, 1)
 	if version == nil {
 		versions = nil
 	} else {
 		versions[0] = *version
 	}
-	payload := models.Issue{
-		Fields: models.Fields{
-			Summary:     summary,
-			Project:     &models.Project{Key: projectKey},
-			Description: description,
-			IssueType:   &models.IssueType{Name: issueType},
-			FixVersions: versions,
-		},
+	fields := models.Fields{
+		Summary:     summary,
+		Project:     &models.Project{Key: projectKey},
+		Description: models.RichText(description),
+		IssueType:   &models.IssueType{Name: issueType},
+		FixVersions: versions,
+	}
+	return c.CreateIssueWithFields(ctx, fields)
+}
+
+// CreateIssueWithFields method creates issue with given fields, including custom fields set with SetFields
+func (c *Client) CreateIssueWithFields(ctx context.Context, fields models.Fields) (models.Issue, error) {
+	fields, err := c.createFields(ctx, fields)
+	if err != nil {
+		return models.Issue{}, err
 	}
 	response := models.Issue{}
-	_, err := execute(resty.MethodPost, "rest/api/2/issue", payload, &response, "", nil)
+	_, err = c.execute(ctx, resty.MethodPost, "rest/api/2/issue", models.Issue{Fields: fields}, &response, "", nil)
 	return response, err
 }
+
+// createFields method returns fields of created issue with description converted to document on JIRA Cloud.
+// Fields which can't be encoded are rejected, so single invalid issue doesn't break bulk request
+func (c *Client) createFields(ctx context.Context, fields models.Fields) (models.Fields, error) {
+	if _, err := json.Marshal(fields); err != nil {
+		return fields, err
+	}
+	flavor, err := c.Flavor(ctx)
+	if err != nil {
+		return fields, err
+	}
+	if flavor == FlavorCloud && fields.Description != "" {
+		if err := fields.Set("description", models.NewDocument(string(fields.Description))); err != nil {
+			return fields, err
+		}
+		fields.Description = ""
+	}
+	return fields, nil
+}
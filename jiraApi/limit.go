@@ -0,0 +1,126 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter spreads requests evenly, so no more than given number of requests is sent per second
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+}
+
+// reserve returns time caller has to wait before sending request
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	return wait
+}
+
+// WithConcurrency option limits number of requests sent at the same time and
+// number of goroutines started by ForEach. Zero means no limit
+func WithConcurrency(concurrency int) Option {
+	return func(c *Client) {
+		c.concurrency = concurrency
+	}
+}
+
+// WithRateLimit option limits number of requests sent per second. Zero means no limit
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(requestsPerSecond)
+	}
+}
+
+// Concurrency method returns maximal number of requests sent by client at the same time
+func (c *Client) Concurrency() int {
+	return c.concurrency
+}
+
+// acquire blocks until request can be sent, returned function must be called when response is read.
+// It returns context error when context is done before request can be sent
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if c.limiter != nil {
+		if wait := c.limiter.reserve(); wait > 0 {
+			if err := c.sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if c.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case c.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return func() {
+		<-c.slots
+	}, nil
+}
+
+// ForEach method calls fn for every key in separate goroutine and waits until all calls are done.
+// Number of goroutines running at the same time is limited by client concurrency.
+// When context is done remaining keys are not passed to fn
+func (c *Client) ForEach(ctx context.Context, keys []string, fn func(key string)) {
+	workers := c.concurrency
+	if workers <= 0 || workers > len(keys) {
+		workers = len(keys)
+	}
+	queue := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range queue {
+				fn(key)
+			}
+		}()
+	}
+	for _, key := range keys {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case queue <- key:
+		case <-ctx.Done():
+		}
+	}
+	close(queue)
+	wg.Wait()
+}
@@ -0,0 +1,124 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachConcurrency(t *testing.T) {
+	c := NewClient("https://jira.example.com", "user", "pass", WithConcurrency(3))
+	keys := []string{"TEST-1", "TEST-2", "TEST-3", "TEST-4", "TEST-5", "TEST-6", "TEST-7", "TEST-8"}
+	var running, maxRunning int32
+	var mu sync.Mutex
+	visited := make(map[string]bool)
+
+	c.ForEach(context.Background(), keys, func(key string) {
+		n := atomic.AddInt32(&running, 1)
+		mu.Lock()
+		visited[key] = true
+		if n > maxRunning {
+			maxRunning = n
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	})
+
+	assert.Equal(t, len(visited), len(keys))
+	if maxRunning > 3 {
+		t.Errorf("TestForEachConcurrency: expected at most 3 goroutines, got: %d", maxRunning)
+	}
+}
+
+func TestRequestConcurrency(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass", WithConcurrency(2))
+	response := readResponse("./responses/issue/TEST-1.json")
+	var running, maxRunning int32
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/TEST-1",
+		func(req *http.Request) (*http.Response, error) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			return httpmock.NewStringResponse(200, response), nil
+		})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.GetIssue(context.Background(), "TEST-1")
+		}()
+	}
+	wg.Wait()
+
+	if maxRunning > 2 {
+		t.Errorf("TestRequestConcurrency: expected at most 2 requests at the same time, got: %d", maxRunning)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(4)
+	assert.Equal(t, limiter.reserve(), time.Duration(0))
+	for i := 1; i < 4; i++ {
+		wait := limiter.reserve()
+		expected := time.Duration(i) * 250 * time.Millisecond
+		if wait > expected || wait < expected-50*time.Millisecond {
+			t.Errorf("TestRateLimiter: request %d: expected wait about %s, got: %s", i, expected, wait)
+		}
+	}
+	assert.Assert(t, newRateLimiter(0) == nil)
+}
+
+func TestForEachCancelled(t *testing.T) {
+	c := NewClient("https://jira.example.com", "user", "pass", WithConcurrency(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	visited := make([]string, 0)
+
+	c.ForEach(ctx, []string{"TEST-1", "TEST-2", "TEST-3"}, func(key string) {
+		visited = append(visited, key)
+		cancel()
+	})
+
+	assert.DeepEqual(t, visited, []string{"TEST-1"})
+}
+
+func TestRequestCancelled(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass", WithConcurrency(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.GetIssue(ctx, "TEST-1")
+	assert.Equal(t, err, context.Canceled)
+	assert.Equal(t, httpmock.GetTotalCallCount(), 0)
+}
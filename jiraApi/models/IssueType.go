@@ -2,5 +2,6 @@ package models
 
 // IssueType type represents JIRA issue type
 type IssueType struct {
+	Id   string `json:"id,omitempty"`
 	Name string `json:"name"`
 }
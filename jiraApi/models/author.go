@@ -1,6 +1,17 @@
 package models
 
-// Type represents JIRA author object
+// Type represents JIRA author object. Name is set by JIRA Server, AccountId by JIRA Cloud
 type Author struct {
-	Name string `json:"name"`
+	Name         string `json:"name"`
+	AccountId    string `json:"accountId,omitempty"`
+	DisplayName  string `json:"displayName,omitempty"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+}
+
+// String method returns user name or display name when user name is not available
+func (a Author) String() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return a.DisplayName
 }
@@ -0,0 +1,27 @@
+package models
+
+// Changelog type represents history of issue changes
+type Changelog struct {
+	StartAt    int                `json:"startAt"`
+	MaxResults int                `json:"maxResults"`
+	Total      int                `json:"total"`
+	Histories  []ChangelogHistory `json:"histories"`
+}
+
+// ChangelogHistory type represents fields changed together by user
+type ChangelogHistory struct {
+	Id      string          `json:"id,omitempty"`
+	Author  Author          `json:"author"`
+	Created string          `json:"created,omitempty"`
+	Items   []ChangelogItem `json:"items"`
+}
+
+// ChangelogItem type represents change of single field
+type ChangelogItem struct {
+	Field      string `json:"field"`
+	FieldType  string `json:"fieldtype,omitempty"`
+	From       string `json:"from,omitempty"`
+	FromString string `json:"fromString,omitempty"`
+	To         string `json:"to,omitempty"`
+	ToString   string `json:"toString,omitempty"`
+}
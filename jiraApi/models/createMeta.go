@@ -0,0 +1,41 @@
+package models
+
+import "sort"
+
+// CreateMeta type represents projects with issue types and fields which can be set when issue is created
+type CreateMeta struct {
+	Projects []CreateMetaProject `json:"projects"`
+}
+
+// CreateMetaProject type represents project in which user can create issues
+type CreateMetaProject struct {
+	Id         string          `json:"id,omitempty"`
+	Key        string          `json:"key,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	IssueTypes []IssueTypeMeta `json:"issuetypes"`
+}
+
+// IssueTypeMeta type represents issue type with fields of its create screen by field id
+type IssueTypeMeta struct {
+	Id      string               `json:"id,omitempty"`
+	Name    string               `json:"name,omitempty"`
+	Subtask bool                 `json:"subtask"`
+	Fields  map[string]FieldMeta `json:"fields,omitempty"`
+}
+
+// MissingFields method returns sorted ids of required fields which have no value nor default value.
+// Project and issue type are not checked
+func (m IssueTypeMeta) MissingFields(fields Fields) []string {
+	set := make(map[string]bool)
+	for _, id := range fields.Ids() {
+		set[id] = true
+	}
+	missing := make([]string, 0)
+	for id, meta := range m.Fields {
+		if meta.Required && !meta.HasDefaultValue && !set[id] && id != "project" && id != "issuetype" {
+			missing = append(missing, id)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
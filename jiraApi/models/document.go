@@ -0,0 +1,67 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Document type represents node of Atlassian Document Format used for rich text by JIRA Cloud REST API v3
+type Document struct {
+	Type    string                 `json:"type"`
+	Version int                    `json:"version,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Marks   []Document             `json:"marks,omitempty"`
+	Content []Document             `json:"content,omitempty"`
+}
+
+// NewDocument converts plain text to document. Blank lines separate paragraphs, other line breaks are kept
+func NewDocument(text string) *Document {
+	doc := &Document{Type: "doc", Version: 1, Content: make([]Document, 0)}
+	for _, p := range strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n\n") {
+		paragraph := Document{Type: "paragraph"}
+		for i, line := range strings.Split(p, "\n") {
+			if i > 0 {
+				paragraph.Content = append(paragraph.Content, Document{Type: "hardBreak"})
+			}
+			if line != "" {
+				paragraph.Content = append(paragraph.Content, Document{Type: "text", Text: line})
+			}
+		}
+		doc.Content = append(doc.Content, paragraph)
+	}
+	return doc
+}
+
+// String method converts document to plain text. Formatting is dropped, block nodes are separated with blank line
+func (d Document) String() string {
+	switch d.Type {
+	case "text":
+		return d.Text
+	case "hardBreak":
+		return "\n"
+	case "mention", "emoji", "status", "date":
+		for _, attr := range []string{"text", "shortName", "timestamp"} {
+			if value, ok := d.Attrs[attr]; ok {
+				return fmt.Sprint(value)
+			}
+		}
+		return ""
+	}
+	separator := ""
+	switch d.Type {
+	case "doc", "blockquote", "panel", "tableCell", "tableHeader":
+		separator = "\n\n"
+	case "bulletList", "orderedList", "table", "tableRow":
+		separator = "\n"
+	}
+	parts := make([]string, 0, len(d.Content))
+	for _, node := range d.Content {
+		text := node.String()
+		if node.Type == "listItem" {
+			text = "- " + text
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, separator)
+}
@@ -0,0 +1,6 @@
+package models
+
+// EditMeta type represents fields of issue which can be edited by user, by field id
+type EditMeta struct {
+	Fields map[string]FieldMeta `json:"fields"`
+}
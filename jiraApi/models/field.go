@@ -0,0 +1,10 @@
+package models
+
+// Field type represents JIRA issue field, system or custom
+type Field struct {
+	Id     string       `json:"id"`
+	Key    string       `json:"key,omitempty"`
+	Name   string       `json:"name"`
+	Custom bool         `json:"custom"`
+	Schema *FieldSchema `json:"schema,omitempty"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// FieldMeta type represents field of issue edit or create screen with operations and values it accepts
+type FieldMeta struct {
+	FieldId         string            `json:"fieldId,omitempty"`
+	Required        bool              `json:"required"`
+	HasDefaultValue bool              `json:"hasDefaultValue,omitempty"`
+	Schema          *FieldSchema      `json:"schema,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Key             string            `json:"key,omitempty"`
+	Operations      []string          `json:"operations,omitempty"`
+	AllowedValues   []json.RawMessage `json:"allowedValues,omitempty"`
+}
+
+// allowedValue type represents identifiers of value allowed for field, e.g. priority, version or option
+type allowedValue struct {
+	Id    string `json:"id"`
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Allows method returns true when field has no list of allowed values or value matches id, key, name
+// or value of one of them. Matching is case insensitive
+func (m FieldMeta) Allows(value string) bool {
+	if len(m.AllowedValues) == 0 {
+		return true
+	}
+	for _, raw := range m.AllowedValues {
+		allowed := allowedValue{}
+		if err := json.Unmarshal(raw, &allowed); err != nil {
+			continue
+		}
+		for _, v := range []string{allowed.Id, allowed.Key, allowed.Name, allowed.Value} {
+			if v != "" && strings.EqualFold(v, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AllowedNames method returns names of allowed values, or their values when they have no names
+func (m FieldMeta) AllowedNames() []string {
+	names := make([]string, 0, len(m.AllowedValues))
+	for _, raw := range m.AllowedValues {
+		allowed := allowedValue{}
+		if err := json.Unmarshal(raw, &allowed); err != nil {
+			continue
+		}
+		switch {
+		case allowed.Name != "":
+			names = append(names, allowed.Name)
+		case allowed.Value != "":
+			names = append(names, allowed.Value)
+		default:
+			names = append(names, allowed.Id)
+		}
+	}
+	return names
+}
+
+// Supports method returns true when field accepts given update operation
+func (m FieldMeta) Supports(operation string) bool {
+	for _, o := range m.Operations {
+		if o == operation {
+			return true
+		}
+	}
+	return false
+}
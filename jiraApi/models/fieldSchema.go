@@ -0,0 +1,10 @@
+package models
+
+// FieldSchema type represents type of JIRA field value
+type FieldSchema struct {
+	Type     string `json:"type,omitempty"`
+	Items    string `json:"items,omitempty"`
+	System   string `json:"system,omitempty"`
+	Custom   string `json:"custom,omitempty"`
+	CustomId int64  `json:"customId,omitempty"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// FieldText function returns readable text of field value: option value, user or version name,
+// document text or comma separated values of array
+func FieldText(value json.RawMessage) string {
+	if len(value) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(value, &v); err != nil {
+		return string(value)
+	}
+	return valueText(v)
+}
+
+func valueText(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			items = append(items, valueText(item))
+		}
+		return strings.Join(items, ", ")
+	case map[string]interface{}:
+		if value["type"] == "doc" {
+			data, _ := json.Marshal(value)
+			doc := Document{}
+			if err := json.Unmarshal(data, &doc); err == nil {
+				return doc.String()
+			}
+		}
+		if option, ok := value["value"]; ok {
+			if child, ok := value["child"]; ok {
+				return valueText(option) + " / " + valueText(child)
+			}
+			return valueText(option)
+		}
+		for _, key := range []string{"displayName", "name", "key", "id"} {
+			if text, ok := value[key]; ok {
+				return valueText(text)
+			}
+		}
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -1,11 +1,132 @@
 package models
 
-// Fields type represents fields of JIRA issue
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Fields type represents fields of JIRA issue. Fields without struct field, e.g. custom fields,
+// are kept in Extra by field id
 type Fields struct {
-	FixVersions []Version  `json:"fixVersions,omitempty"`
-	Status      *Status    `json:"status,omitempty"`
-	Summary     string     `json:"summary,omitempty"`
-	Project     *Project   `json:"project,omitempty"`
-	IssueType   *IssueType `json:"issuetype,omitempty"`
-	Description string     `json:"description,omitempty"`
+	FixVersions []Version                  `json:"fixVersions,omitempty"`
+	Status      *Status                    `json:"status,omitempty"`
+	Summary     string                     `json:"summary,omitempty"`
+	Project     *Project                   `json:"project,omitempty"`
+	IssueType   *IssueType                 `json:"issuetype,omitempty"`
+	Description RichText                   `json:"description,omitempty"`
+	Extra       map[string]json.RawMessage `json:"-"`
+}
+
+// knownFields holds ids of fields decoded into Fields struct fields
+var knownFields = func() map[string]bool {
+	known := make(map[string]bool)
+	t := reflect.TypeOf(Fields{})
+	for i := 0; i < t.NumField(); i++ {
+		if name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]; name != "-" {
+			known[name] = true
+		}
+	}
+	return known
+}()
+
+// UnmarshalJSON method decodes known fields and keeps other non null fields in Extra
+func (f *Fields) UnmarshalJSON(data []byte) error {
+	type fields Fields
+	known := fields{}
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+	all := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	*f = Fields(known)
+	for id, value := range all {
+		if knownFields[id] || string(value) == "null" {
+			continue
+		}
+		if f.Extra == nil {
+			f.Extra = make(map[string]json.RawMessage)
+		}
+		f.Extra[id] = value
+	}
+	return nil
+}
+
+// MarshalJSON method encodes known fields together with Extra fields. Extra field replaces known field with the same id
+func (f Fields) MarshalJSON() ([]byte, error) {
+	type fields Fields
+	data, err := json.Marshal(fields(f))
+	if err != nil || len(f.Extra) == 0 {
+		return data, err
+	}
+	merged := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for id, value := range f.Extra {
+		merged[id] = value
+	}
+	return json.Marshal(merged)
+}
+
+// Get method decodes value of extra field into v. It returns false when issue has no such field
+func (f Fields) Get(id string, v interface{}) (bool, error) {
+	value, ok := f.Extra[id]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(value, v)
+}
+
+// Set method sets value of extra field
+func (f *Fields) Set(id string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	extra := make(map[string]json.RawMessage, len(f.Extra)+1)
+	for k, v := range f.Extra {
+		extra[k] = v
+	}
+	extra[id] = data
+	f.Extra = extra
+	return nil
+}
+
+// Ids method returns sorted ids of fields which have value
+func (f Fields) Ids() []string {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil
+	}
+	values := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil
+	}
+	ids := make([]string, 0, len(values))
+	for id, value := range values {
+		if string(value) != "null" {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Has method returns true when field with given id has value
+func (f Fields) Has(id string) bool {
+	for _, i := range f.Ids() {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Text method returns value of extra field as text. See FieldText
+func (f Fields) Text(id string) string {
+	return FieldText(f.Extra[id])
 }
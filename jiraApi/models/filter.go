@@ -0,0 +1,9 @@
+package models
+
+// Filter type represents JIRA saved filter resource
+type Filter struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Jql  string `json:"jql,omitempty"`
+	Self string `json:"self,omitempty"`
+}
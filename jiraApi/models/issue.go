@@ -1,9 +1,15 @@
 package models
 
-// Issue type represents JIRA issue resource
+// Issue type represents JIRA issue resource. RenderedFields, Names, Schema, Changelog and Transitions
+// are set only when requested with expand parameter
 type Issue struct {
-	Id     string `json:"id,omitempty"`
-	Key    string `json:"key,omitempty"`
-	Fields Fields `json:"fields,omitempty"`
-	Self   string `json:"self,omitempty"`
+	Id             string                 `json:"id,omitempty"`
+	Key            string                 `json:"key,omitempty"`
+	Fields         Fields                 `json:"fields,omitempty"`
+	Self           string                 `json:"self,omitempty"`
+	RenderedFields map[string]interface{} `json:"renderedFields,omitempty"`
+	Names          map[string]string      `json:"names,omitempty"`
+	Schema         map[string]FieldSchema `json:"schema,omitempty"`
+	Changelog      *Changelog             `json:"changelog,omitempty"`
+	Transitions    []Transition           `json:"transitions,omitempty"`
 }
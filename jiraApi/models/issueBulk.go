@@ -0,0 +1,26 @@
+package models
+
+// IssueBulkRequest type represents payload of bulk issue creation
+type IssueBulkRequest struct {
+	IssueUpdates []Issue `json:"issueUpdates"`
+}
+
+// IssueBulkResponse type represents response of bulk issue creation. Issues contains created issues
+// in order of request, errors refer to failed issues by their position in request
+type IssueBulkResponse struct {
+	Issues []Issue          `json:"issues"`
+	Errors []IssueBulkError `json:"errors"`
+}
+
+// IssueBulkError type represents failure of single issue of bulk creation
+type IssueBulkError struct {
+	Status              int                `json:"status"`
+	ElementErrors       IssueElementErrors `json:"elementErrors"`
+	FailedElementNumber int                `json:"failedElementNumber"`
+}
+
+// IssueElementErrors type represents messages of failed issue of bulk creation
+type IssueElementErrors struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// IssueLinkType type represents type of link between issues, e.g. Blocks with inward "is blocked by" and outward "blocks"
+type IssueLinkType struct {
+	Id      string `json:"id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Inward  string `json:"inward,omitempty"`
+	Outward string `json:"outward,omitempty"`
+}
+
+// IssueLink type represents payload of link between issues
+type IssueLink struct {
+	Type         IssueLinkType `json:"type"`
+	InwardIssue  IssueRef      `json:"inwardIssue"`
+	OutwardIssue IssueRef      `json:"outwardIssue"`
+}
+
+// IssueRef type represents reference to issue by key
+type IssueRef struct {
+	Key string `json:"key"`
+}
@@ -0,0 +1,15 @@
+package models
+
+// IssueUpdate type represents payload of issue edit with list of operations of every field,
+// e.g. {"update": {"labels": [{"add": "ci"}, {"remove": "draft"}]}}
+type IssueUpdate struct {
+	Update map[string][]map[string]interface{} `json:"update"`
+}
+
+// Add method appends operation of field
+func (u *IssueUpdate) Add(fieldId string, operation string, value interface{}) {
+	if u.Update == nil {
+		u.Update = make(map[string][]map[string]interface{})
+	}
+	u.Update[fieldId] = append(u.Update[fieldId], map[string]interface{}{operation: value})
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"gotest.tools/assert"
 	"testing"
 	"time"
@@ -17,3 +18,115 @@ func TestInitilizeWorklogAddCrashOnValidation(t *testing.T) {
 	_, e := InitilizeWorklogAdd("T", 1, "3", "dd")
 	assert.Error(t, e, "If provided the date and time must adhere to formats: [YYYY-MM-DD] and [HH:ss]. You provided: date=[ 3 ] and time=[ dd ]\n")
 }
+
+func TestNewDocument(t *testing.T) {
+	text := "First paragraph\nsecond line\n\nSecond paragraph"
+	doc := NewDocument(text)
+	assert.Equal(t, len(doc.Content), 2)
+	assert.Equal(t, len(doc.Content[0].Content), 3)
+	assert.Equal(t, doc.Content[0].Content[1].Type, "hardBreak")
+	assert.Equal(t, doc.String(), text)
+}
+
+func TestRichTextUnmarshal(t *testing.T) {
+	fields := Fields{}
+	assert.NilError(t, json.Unmarshal([]byte(`{"description":"plain text"}`), &fields))
+	assert.Equal(t, fields.Description, RichText("plain text"))
+
+	fields = Fields{}
+	assert.NilError(t, json.Unmarshal([]byte(`{"description":null}`), &fields))
+	assert.Equal(t, fields.Description, RichText(""))
+
+	fields = Fields{}
+	doc := `{"description":{"type":"doc","version":1,"content":[{"type":"bulletList","content":[` +
+		`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]}]},` +
+		`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]}]}]}]}}`
+	assert.NilError(t, json.Unmarshal([]byte(doc), &fields))
+	assert.Equal(t, fields.Description, RichText("- one\n- two"))
+}
+
+func TestFieldsExtra(t *testing.T) {
+	fields := Fields{}
+	data := `{"summary":"test","customfield_10002":5,"customfield_10003":null,"labels":["a","b"]}`
+	assert.NilError(t, json.Unmarshal([]byte(data), &fields))
+	assert.Equal(t, fields.Summary, "test")
+	assert.Equal(t, len(fields.Extra), 2)
+	points := 0.0
+	ok, err := fields.Get("customfield_10002", &points)
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, points, 5.0)
+	assert.Equal(t, fields.Text("labels"), "a, b")
+
+	assert.NilError(t, fields.Set("customfield_10004", map[string]string{"value": "Red"}))
+	encoded, err := json.Marshal(fields)
+	assert.NilError(t, err)
+	assert.Equal(t, string(encoded), `{"customfield_10002":5,"customfield_10004":{"value":"Red"},"labels":["a","b"],"summary":"test"}`)
+}
+
+func TestFieldText(t *testing.T) {
+	assert.Equal(t, FieldText(json.RawMessage(`"text"`)), "text")
+	assert.Equal(t, FieldText(json.RawMessage(`2.5`)), "2.5")
+	assert.Equal(t, FieldText(json.RawMessage(`{"value":"Red","child":{"value":"Dark"}}`)), "Red / Dark")
+	assert.Equal(t, FieldText(json.RawMessage(`[{"name":"sotomski","displayName":"Robert Sotomski"}]`)), "Robert Sotomski")
+	assert.Equal(t, FieldText(json.RawMessage(`{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"notes"}]}]}`)), "notes")
+	assert.Equal(t, FieldText(nil), "")
+}
+
+func TestFieldMetaAllows(t *testing.T) {
+	meta := FieldMeta{}
+	assert.NilError(t, json.Unmarshal([]byte(`{"required":false,"operations":["set"],"allowedValues":[{"id":"1","name":"Highest"},{"id":"10100","value":"Backend"}]}`), &meta))
+	assert.Assert(t, meta.Allows("highest"))
+	assert.Assert(t, meta.Allows("10100"))
+	assert.Assert(t, meta.Allows("Backend"))
+	assert.Assert(t, !meta.Allows("Lowest"))
+	assert.DeepEqual(t, meta.AllowedNames(), []string{"Highest", "Backend"})
+	assert.Assert(t, meta.Supports("set"))
+	assert.Assert(t, !meta.Supports("add"))
+}
+
+func TestIssueUpdate(t *testing.T) {
+	update := IssueUpdate{}
+	update.Add("labels", "add", "ci")
+	update.Add("labels", "remove", "draft")
+	update.Add("summary", "set", "New summary")
+	payload, err := json.Marshal(update)
+	assert.NilError(t, err)
+	assert.Equal(t, string(payload), `{"update":{"labels":[{"add":"ci"},{"remove":"draft"}],"summary":[{"set":"New summary"}]}}`)
+}
+
+func TestIssueTypeMetaMissingFields(t *testing.T) {
+	meta := IssueTypeMeta{Fields: map[string]FieldMeta{
+		"project":           {Required: true},
+		"summary":           {Required: true},
+		"priority":          {Required: true, HasDefaultValue: true},
+		"components":        {Required: true},
+		"customfield_10002": {Required: true},
+		"labels":            {},
+	}}
+	fields := Fields{Summary: "Summary"}
+	assert.NilError(t, fields.Set("customfield_10002", 5))
+	assert.DeepEqual(t, fields.Ids(), []string{"customfield_10002", "summary"})
+	assert.Assert(t, fields.Has("summary"))
+	assert.Assert(t, !fields.Has("description"))
+	assert.DeepEqual(t, meta.MissingFields(fields), []string{"components"})
+}
+
+func TestCloudWorkflow(t *testing.T) {
+	cloudWorkflow := CloudWorkflow{
+		Id:       CloudWorkflowId{Name: "test-workflow"},
+		Statuses: []CloudWorkflowStatus{{Id: "1", Name: "To Do"}, {Id: "3", Name: "In Progress"}, {Id: "5", Name: "Done"}},
+		Transitions: []CloudWorkflowTransition{
+			{Id: "1", Name: "Create", To: "1", Type: "initial"},
+			{Id: "11", Name: "Start", From: []string{"1"}, To: "3", Type: "directed"},
+			{Id: "21", Name: "Done", To: "5", Type: "global"},
+		},
+	}
+	w := cloudWorkflow.Workflow()
+	assert.DeepEqual(t, w.Layout.Statuses[1], Status{Id: "3", Name: "In Progress", StatusId: "3"})
+	assert.DeepEqual(t, w.Layout.Transitions, []Transition{
+		{Id: "11", Name: "Start", SourceId: "1", TargetId: "3", ActionId: 11},
+		{Id: "21", Name: "Done", SourceId: "1", TargetId: "5", ActionId: 21, GlobalTransition: true},
+		{Id: "21", Name: "Done", SourceId: "3", TargetId: "5", ActionId: 21, GlobalTransition: true},
+	})
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"encoding/json"
+)
+
+// RichText type represents text field. REST API v2 returns it as string,
+// REST API v3 as Atlassian Document Format document, which is converted to plain text
+type RichText string
+
+// UnmarshalJSON method reads text from string or document
+func (t *RichText) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		doc := Document{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		*t = RichText(doc.String())
+		return nil
+	}
+	var text *string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	if text != nil {
+		*t = RichText(*text)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package models
+
+// ServerInfo type represents JIRA server information resource
+type ServerInfo struct {
+	BaseUrl        string `json:"baseUrl"`
+	Version        string `json:"version"`
+	DeploymentType string `json:"deploymentType"`
+}
@@ -9,3 +9,8 @@ type Version struct {
 	ProjectId int    `json:"projectId,omitempty"`
 	Project   string `json:"project,omitempty"`
 }
+
+// String method returns version name
+func (v Version) String() string {
+	return v.Name
+}
@@ -0,0 +1,56 @@
+package models
+
+// WorkflowScheme type represents JIRA workflow scheme mapping issue types to workflows
+type WorkflowScheme struct {
+	Id                int64             `json:"id,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	DefaultWorkflow   string            `json:"defaultWorkflow,omitempty"`
+	IssueTypeMappings map[string]string `json:"issueTypeMappings,omitempty"`
+}
+
+// WorkflowSchemeProjectAssociations type represents workflow schemes assigned to projects
+type WorkflowSchemeProjectAssociations struct {
+	Values []WorkflowSchemeProjectAssociation `json:"values"`
+}
+
+// WorkflowSchemeProjectAssociation type represents workflow scheme assigned to projects
+type WorkflowSchemeProjectAssociation struct {
+	ProjectIds     []string       `json:"projectIds"`
+	WorkflowScheme WorkflowScheme `json:"workflowScheme"`
+}
+
+// ProjectWorkflowScheme type represents workflow scheme returned by Jira Server project configuration resource
+type ProjectWorkflowScheme struct {
+	Id       int64                    `json:"id,omitempty"`
+	Name     string                   `json:"name,omitempty"`
+	Mappings []ProjectWorkflowMapping `json:"mappings"`
+}
+
+// ProjectWorkflowMapping type represents workflow and issue types using it
+type ProjectWorkflowMapping struct {
+	Name       string   `json:"name"`
+	IssueTypes []string `json:"issueTypes"`
+	Default    bool     `json:"default"`
+}
+
+// WorkflowScheme method converts project configuration scheme into WorkflowScheme
+func (s ProjectWorkflowScheme) WorkflowScheme() WorkflowScheme {
+	scheme := WorkflowScheme{Id: s.Id, Name: s.Name, IssueTypeMappings: make(map[string]string)}
+	for _, m := range s.Mappings {
+		if m.Default {
+			scheme.DefaultWorkflow = m.Name
+		}
+		for _, issueType := range m.IssueTypes {
+			scheme.IssueTypeMappings[issueType] = m.Name
+		}
+	}
+	return scheme
+}
+
+// WorkflowName method returns name of workflow used by issue type, falling back to default workflow
+func (s WorkflowScheme) WorkflowName(issueTypeId string) string {
+	if name, ok := s.IssueTypeMappings[issueTypeId]; ok {
+		return name
+	}
+	return s.DefaultWorkflow
+}
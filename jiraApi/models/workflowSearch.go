@@ -0,0 +1,65 @@
+package models
+
+import "strconv"
+
+// WorkflowSearch type represents page of workflows returned by JIRA Cloud workflow search
+type WorkflowSearch struct {
+	Values []CloudWorkflow `json:"values"`
+}
+
+// CloudWorkflow type represents JIRA Cloud workflow with statuses and transitions between status ids
+type CloudWorkflow struct {
+	Id          CloudWorkflowId           `json:"id"`
+	Statuses    []CloudWorkflowStatus     `json:"statuses"`
+	Transitions []CloudWorkflowTransition `json:"transitions"`
+}
+
+// CloudWorkflowId type represents name and entity id of JIRA Cloud workflow
+type CloudWorkflowId struct {
+	Name     string `json:"name"`
+	EntityId string `json:"entityId,omitempty"`
+}
+
+// CloudWorkflowStatus type represents status of JIRA Cloud workflow
+type CloudWorkflowStatus struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CloudWorkflowTransition type represents transition of JIRA Cloud workflow. Global transitions have no source
+// statuses and lead from every status, initial transition creates issue
+type CloudWorkflowTransition struct {
+	Id   string   `json:"id"`
+	Name string   `json:"name"`
+	From []string `json:"from"`
+	To   string   `json:"to"`
+	Type string   `json:"type"`
+}
+
+// Workflow method converts JIRA Cloud workflow into Workflow with statuses identified by status id
+func (w CloudWorkflow) Workflow() Workflow {
+	workflow := Workflow{}
+	for _, s := range w.Statuses {
+		workflow.Layout.Statuses = append(workflow.Layout.Statuses, Status{Id: s.Id, Name: s.Name, StatusId: s.Id})
+	}
+	for _, t := range w.Transitions {
+		actionId, _ := strconv.ParseUint(t.Id, 10, 32)
+		transition := Transition{Id: t.Id, Name: t.Name, TargetId: t.To, ActionId: uint(actionId), Initial: t.Type == "initial"}
+		sources := t.From
+		if t.Type == "global" {
+			transition.GlobalTransition = true
+			sources = make([]string, 0, len(w.Statuses))
+			for _, s := range w.Statuses {
+				if s.Id != t.To {
+					sources = append(sources, s.Id)
+				}
+			}
+		}
+		for _, source := range sources {
+			transition.SourceId = source
+			transition.LoopedTransition = source == t.To
+			workflow.Layout.Transitions = append(workflow.Layout.Transitions, transition)
+		}
+	}
+	return workflow
+}
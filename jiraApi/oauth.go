@@ -0,0 +1,215 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OAuth1 type signs requests with OAuth 1.0a RSA-SHA1 signature, as required by JIRA application links
+type OAuth1 struct {
+	ConsumerKey string
+	PrivateKey  *rsa.PrivateKey
+	// Token is access token authorized by user
+	Token string
+}
+
+// NewOAuth1 method creates OAuth authenticator with RSA key read from PEM file
+func NewOAuth1(consumerKey string, privateKeyFile string, token string) (OAuth1, error) {
+	if consumerKey == "" || privateKeyFile == "" {
+		return OAuth1{}, fmt.Errorf("%s authentication requires consumer key and private key file", AuthOAuth1)
+	}
+	key, err := LoadRSAPrivateKey(privateKeyFile)
+	if err != nil {
+		return OAuth1{}, err
+	}
+	return OAuth1{ConsumerKey: consumerKey, PrivateKey: key, Token: token}, nil
+}
+
+// LoadRSAPrivateKey method reads RSA private key from PKCS #1 or PKCS #8 PEM file
+func LoadRSAPrivateKey(file string) (*rsa.PrivateKey, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read private key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("cannot read private key: no PEM data found in %s", file)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read private key %s: %w", file, err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("cannot read private key %s: OAuth requires RSA key", file)
+	}
+	return rsaKey, nil
+}
+
+// Authenticate method sets OAuth authorization header signed with access token
+func (a OAuth1) Authenticate(req *http.Request) error {
+	return a.sign(req, map[string]string{"oauth_token": a.Token})
+}
+
+// RequestToken method obtains temporary token and returns URL on which user authorizes it
+func (a OAuth1) RequestToken(ctx context.Context, httpClient *http.Client, serverUrl string) (token string, authorizeUrl string, err error) {
+	values, err := a.tokenRequest(ctx, httpClient, serverUrl+"/plugins/servlet/oauth/request-token", map[string]string{
+		"oauth_callback": "oob",
+	})
+	if err != nil {
+		return "", "", err
+	}
+	token = values.Get("oauth_token")
+	return token, fmt.Sprintf("%s/plugins/servlet/oauth/authorize?oauth_token=%s", serverUrl, url.QueryEscape(token)), nil
+}
+
+// AccessToken method exchanges request token authorized by user for access token
+func (a OAuth1) AccessToken(ctx context.Context, httpClient *http.Client, serverUrl string, requestToken string, verifier string) (string, error) {
+	values, err := a.tokenRequest(ctx, httpClient, serverUrl+"/plugins/servlet/oauth/access-token", map[string]string{
+		"oauth_token":    requestToken,
+		"oauth_verifier": verifier,
+	})
+	if err != nil {
+		return "", err
+	}
+	return values.Get("oauth_token"), nil
+}
+
+// tokenRequest sends signed request to OAuth token endpoint and returns form encoded response
+func (a OAuth1) tokenRequest(ctx context.Context, httpClient *http.Client, endpoint string, params map[string]string) (url.Values, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.sign(req, params); err != nil {
+		return nil, err
+	}
+	res, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OAuth token request failed: %s: %s", res.Status, body)
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+	if values.Get("oauth_token") == "" {
+		return nil, errors.New("OAuth token request failed: response does not contain token")
+	}
+	return values, nil
+}
+
+// sign sets authorization header with OAuth parameters and signature
+func (a OAuth1) sign(req *http.Request, params map[string]string) error {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	oauth := map[string]string{
+		"oauth_consumer_key":     a.ConsumerKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": "RSA-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	for k, v := range params {
+		if v != "" {
+			oauth[k] = v
+		}
+	}
+	hash := sha1.Sum([]byte(signatureBase(req, oauth)))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.PrivateKey, crypto.SHA1, hash[:])
+	if err != nil {
+		return err
+	}
+	oauth["oauth_signature"] = base64.StdEncoding.EncodeToString(signature)
+
+	header := make([]string, 0, len(oauth))
+	for k, v := range oauth {
+		header = append(header, fmt.Sprintf(`%s="%s"`, oauthEscape(k), oauthEscape(v)))
+	}
+	sort.Strings(header)
+	req.Header.Set("Authorization", "OAuth "+strings.Join(header, ", "))
+	return nil
+}
+
+// signatureBase returns signature base string of request as defined in RFC 5849 section 3.4.1
+func signatureBase(req *http.Request, oauth map[string]string) string {
+	pairs := make([][2]string, 0)
+	for k, values := range req.URL.Query() {
+		for _, v := range values {
+			pairs = append(pairs, [2]string{oauthEscape(k), oauthEscape(v)})
+		}
+	}
+	for k, v := range oauth {
+		pairs = append(pairs, [2]string{oauthEscape(k), oauthEscape(v)})
+	}
+	// parameters are sorted by name and then by value
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+	params := make([]string, len(pairs))
+	for i, pair := range pairs {
+		params[i] = pair[0] + "=" + pair[1]
+	}
+
+	scheme := strings.ToLower(req.URL.Scheme)
+	host := strings.ToLower(req.URL.Host)
+	if port := req.URL.Port(); (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		host = strings.TrimSuffix(host, ":"+port)
+	}
+	baseUrl := scheme + "://" + host + req.URL.EscapedPath()
+	return strings.Join([]string{
+		strings.ToUpper(req.Method),
+		oauthEscape(baseUrl),
+		oauthEscape(strings.Join(params, "&")),
+	}, "&")
+}
+
+// oauthEscape percent encodes string as defined in RFC 5849 section 3.6
+func oauthEscape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
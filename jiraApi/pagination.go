@@ -0,0 +1,149 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"encoding/json"
+	"gopkg.in/resty.v1"
+	"net/url"
+	"strconv"
+)
+
+// PageSize is number of items requested per page from paginated resources
+var PageSize = 100
+
+// page type represents single page of paginated JIRA resource
+type page struct {
+	StartAt    int                        `json:"startAt"`
+	MaxResults int                        `json:"maxResults"`
+	Total      int                        `json:"total"`
+	IsLast     *bool                      `json:"isLast"`
+	Items      map[string]json.RawMessage `json:"-"`
+}
+
+// PageIterator type iterates over pages of paginated JIRA resource.
+// Pages are followed with startAt and total, or with isLast for endpoints which return it
+//
+//	it := client.NewPageIterator(ctx, "rest/api/2/search", query, "issues", 0)
+//	for it.Next() {
+//		issues := make([]models.Issue, 0)
+//		it.Decode(&issues)
+//	}
+//	if it.Err() != nil {
+//		...
+//	}
+type PageIterator struct {
+	ctx      context.Context
+	client   *Client
+	endpoint string
+	query    url.Values
+	itemsKey string
+	limit    int
+	startAt  int
+	fetched  int
+	total    int
+	done     bool
+	items    []json.RawMessage
+	err      error
+}
+
+// NewPageIterator method creates iterator over endpoint returning items in itemsKey field.
+// Limit caps number of fetched items, zero means no limit
+func (c *Client) NewPageIterator(ctx context.Context, endpoint string, query url.Values, itemsKey string, limit int) *PageIterator {
+	if query == nil {
+		query = url.Values{}
+	}
+	return &PageIterator{
+		ctx:      ctx,
+		client:   c,
+		endpoint: endpoint,
+		query:    query,
+		itemsKey: itemsKey,
+		limit:    limit,
+	}
+}
+
+// Next method fetches next page. It returns false when there are no more pages or error occurred
+func (it *PageIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	maxResults := PageSize
+	if it.limit > 0 && it.limit-it.fetched < maxResults {
+		maxResults = it.limit - it.fetched
+	}
+	it.query.Set("startAt", strconv.Itoa(it.startAt))
+	it.query.Set("maxResults", strconv.Itoa(maxResults))
+
+	raw := json.RawMessage{}
+	_, err := it.client.execute(it.ctx, resty.MethodGet, it.endpoint, nil, &raw, it.query.Encode(), nil)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	p := page{}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		it.err = err
+		return false
+	}
+	if err := json.Unmarshal(raw, &p.Items); err != nil {
+		it.err = err
+		return false
+	}
+	it.items = make([]json.RawMessage, 0)
+	if items, ok := p.Items[it.itemsKey]; ok {
+		if err := json.Unmarshal(items, &it.items); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	if it.limit > 0 && it.fetched+len(it.items) > it.limit {
+		it.items = it.items[:it.limit-it.fetched]
+	}
+	it.total = p.Total
+	it.fetched += len(it.items)
+	it.startAt = p.StartAt + len(it.items)
+	switch {
+	case len(it.items) == 0:
+		it.done = true
+	case it.limit > 0 && it.fetched >= it.limit:
+		it.done = true
+	case p.IsLast != nil:
+		it.done = *p.IsLast
+	default:
+		it.done = it.startAt >= p.Total
+	}
+	return true
+}
+
+// Decode method unmarshals items of current page into v, which should be pointer to slice
+func (it *PageIterator) Decode(v interface{}) error {
+	items, err := json.Marshal(it.items)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(items, v)
+}
+
+// Total method returns total number of items reported by server
+func (it *PageIterator) Total() int {
+	return it.total
+}
+
+// Err method returns error which stopped iteration
+func (it *PageIterator) Err() error {
+	return it.err
+}
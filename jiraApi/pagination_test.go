@@ -0,0 +1,116 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"net/http"
+	"testing"
+)
+
+// pageResponder responds with file registered for startAt query parameter
+func pageResponder(t *testing.T, pages map[string]string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		startAt := req.URL.Query().Get("startAt")
+		file, ok := pages[startAt]
+		if !ok {
+			t.Errorf("unexpected page request: %s", req.URL)
+			return httpmock.NewStringResponse(400, ""), nil
+		}
+		return httpmock.NewStringResponse(200, readResponse(file)), nil
+	}
+}
+
+func TestSearchIssuesPagination(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/search",
+		pageResponder(t, map[string]string{
+			"0": "./responses/search/page1.json",
+			"2": "./responses/search/page2.json",
+			"4": "./responses/search/page3.json",
+		}))
+
+	issues, err := c.SearchIssues(context.Background(), "project = TEST", []string{"summary"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, httpmock.GetTotalCallCount(), 3)
+	assert.Equal(t, issues.Total, 5)
+	keys := make([]string, 0)
+	for _, issue := range issues.Issues {
+		keys = append(keys, issue.Key)
+	}
+	assert.DeepEqual(t, keys, []string{"TEST-1", "TEST-2", "TEST-3", "TEST-4", "TEST-5"})
+}
+
+func TestSearchIssuesLimit(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	maxResults := make([]string, 0)
+	pages := pageResponder(t, map[string]string{
+		"0": "./responses/search/page1.json",
+		"2": "./responses/search/page2.json",
+	})
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/search",
+		func(req *http.Request) (*http.Response, error) {
+			maxResults = append(maxResults, req.URL.Query().Get("maxResults"))
+			return pages(req)
+		})
+
+	issues, err := c.SearchIssues(context.Background(), "project = TEST", nil, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(issues.Issues), 3)
+	assert.Equal(t, issues.Issues[2].Key, "TEST-3")
+	assert.DeepEqual(t, maxResults, []string{"3", "1"})
+}
+
+func TestGetProjectsIsLast(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/project/search",
+		pageResponder(t, map[string]string{
+			"0": "./responses/project/search.json",
+			"1": "./responses/project/search2.json",
+		}))
+
+	projects, err := c.GetProjects(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, httpmock.GetTotalCallCount(), 2)
+	assert.Equal(t, len(projects), 2)
+	assert.Equal(t, projects[0].Key, "TEST2")
+	assert.Equal(t, projects[1].Key, "TEST")
+}
+
+func TestPageIteratorError(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/search",
+		httpmock.NewStringResponder(400, readResponse("./responses/issue/404.json")))
+
+	it := c.NewPageIterator(context.Background(), "rest/api/2/search", nil, "issues", 0)
+	assert.Assert(t, !it.Next())
+	assert.ErrorContains(t, it.Err(), "http error: 400")
+}
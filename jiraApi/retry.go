@@ -0,0 +1,106 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"gopkg.in/resty.v1"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy type defines when and how long Client waits before request is sent again.
+// Requests are retried on HTTP 429, 502, 503 and 504 responses
+type RetryPolicy struct {
+	// MaxRetries is number of retries after first attempt, zero disables retries
+	MaxRetries int
+	// WaitTime is base of exponential backoff
+	WaitTime time.Duration
+	// MaxWaitTime caps backoff, Retry-After header sent by server is always respected
+	MaxWaitTime time.Duration
+	// RetryNonIdempotent enables retries of POST requests
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is used by clients created without WithRetryPolicy option
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:  3,
+	WaitTime:    1 * time.Second,
+	MaxWaitTime: 30 * time.Second,
+}
+
+var retryStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// WithRetryPolicy option sets retry policy of Client
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case resty.MethodGet, resty.MethodHead, resty.MethodPut, resty.MethodDelete, resty.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// backoff returns time to wait before next attempt and false when request should not be retried
+func (p RetryPolicy) backoff(method string, attempt int, res *resty.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxRetries {
+		return 0, false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(method) {
+		return 0, false
+	}
+	if err != nil || !retryStatusCodes[res.StatusCode()] {
+		return 0, false
+	}
+	if wait, ok := retryAfter(res.Header().Get("Retry-After")); ok {
+		return wait, true
+	}
+	wait := p.WaitTime << uint(attempt)
+	if wait <= 0 || (p.MaxWaitTime > 0 && wait > p.MaxWaitTime) {
+		wait = p.MaxWaitTime
+	}
+	// full jitter in upper half of backoff window
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1)), true
+}
+
+// retryAfter parses Retry-After header given in seconds or as HTTP date
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
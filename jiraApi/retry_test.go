@@ -0,0 +1,156 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// sequenceResponder responds with given status codes in order, last one is repeated
+func sequenceResponder(body string, header http.Header, statusCodes ...int) httpmock.Responder {
+	calls := 0
+	return func(req *http.Request) (*http.Response, error) {
+		code := statusCodes[len(statusCodes)-1]
+		if calls < len(statusCodes) {
+			code = statusCodes[calls]
+		}
+		calls++
+		res := httpmock.NewStringResponse(code, body)
+		for k, v := range header {
+			res.Header[k] = v
+		}
+		return res, nil
+	}
+}
+
+func newRetryTestClient(policy RetryPolicy) (*Client, *[]time.Duration) {
+	waits := make([]time.Duration, 0)
+	c := NewClient("https://jira.example.com", "user", "pass", WithRetryPolicy(policy))
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return c, &waits
+}
+
+func TestRetryOnServiceUnavailable(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c, waits := newRetryTestClient(RetryPolicy{MaxRetries: 3, WaitTime: time.Second, MaxWaitTime: 3 * time.Second})
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/TEST-1",
+		sequenceResponder(readResponse("./responses/issue/TEST-1.json"), nil, 503, 502, 504, 200))
+
+	issue, err := c.GetIssue(context.Background(), "TEST-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, issue.Key, "TEST-1")
+	assert.Equal(t, httpmock.GetTotalCallCount(), 4)
+	assert.Equal(t, len(*waits), 3)
+	limits := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+	for i, wait := range *waits {
+		if wait < limits[i]/2 || wait > limits[i] {
+			t.Errorf("TestRetryOnServiceUnavailable: wait %d: expected between %s and %s, got: %s", i, limits[i]/2, limits[i], wait)
+		}
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c, waits := newRetryTestClient(RetryPolicy{MaxRetries: 2, WaitTime: time.Second, MaxWaitTime: time.Second})
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/TEST-1",
+		sequenceResponder("", nil, 503))
+
+	_, err := c.GetIssue(context.Background(), "TEST-1")
+	assert.Error(t, err, "http error: 503")
+	assert.Equal(t, httpmock.GetTotalCallCount(), 3)
+	assert.Equal(t, len(*waits), 2)
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c, waits := newRetryTestClient(RetryPolicy{MaxRetries: 3, WaitTime: time.Second, MaxWaitTime: time.Second})
+	header := http.Header{"Retry-After": []string{"7"}}
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/TEST-1",
+		sequenceResponder(readResponse("./responses/issue/TEST-1.json"), header, 429, 200))
+
+	_, err := c.GetIssue(context.Background(), "TEST-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.DeepEqual(t, *waits, []time.Duration{7 * time.Second})
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	response := readResponse("./responses/issue/TEST-1/worklog.json")
+	httpmock.RegisterResponder("POST", "https://jira.example.com/rest/api/2/issue/TEST-1/worklog",
+		sequenceResponder(response, nil, 429, 201))
+
+	c, waits := newRetryTestClient(RetryPolicy{MaxRetries: 3, WaitTime: time.Second})
+	_, err := c.AddWorklog(context.Background(), "TEST-1", 60, "comment", "", "")
+	if err == nil {
+		t.Error("TestRetryNonIdempotent: POST should not be retried by default")
+	}
+	assert.Equal(t, len(*waits), 0)
+
+	httpmock.RegisterResponder("POST", "https://jira.example.com/rest/api/2/issue/TEST-1/worklog",
+		sequenceResponder(response, nil, 429, 201))
+	c, waits = newRetryTestClient(RetryPolicy{MaxRetries: 3, WaitTime: time.Second, RetryNonIdempotent: true})
+	_, err = c.AddWorklog(context.Background(), "TEST-1", 60, "comment", "", "")
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, len(*waits), 1)
+}
+
+func TestRetryAfter(t *testing.T) {
+	wait, ok := retryAfter("120")
+	assert.Assert(t, ok)
+	assert.Equal(t, wait, 2*time.Minute)
+
+	wait, ok = retryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	assert.Assert(t, ok)
+	assert.Equal(t, wait, time.Duration(0))
+
+	_, ok = retryAfter("soon")
+	assert.Assert(t, !ok)
+}
+
+func TestRetryWaitCancelled(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass", WithRetryPolicy(RetryPolicy{MaxRetries: 3, WaitTime: time.Minute}))
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/issue/TEST-1",
+		sequenceResponder("", nil, 503))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.GetIssue(ctx, "TEST-1")
+	assert.Equal(t, err, context.DeadlineExceeded)
+	assert.Equal(t, httpmock.GetTotalCallCount(), 1)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("TestRetryWaitCancelled: retry wait was not interrupted, took: %s", elapsed)
+	}
+}
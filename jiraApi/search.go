@@ -0,0 +1,245 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sotomskir/jira-cli/jiraApi/models"
+	"gopkg.in/resty.v1"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SearchQuery type represents issue search built from JQL, saved filter and shortcut conditions.
+// Values of conditions are quoted, so they can't change meaning of query
+type SearchQuery struct {
+	JQL      string
+	Filter   string
+	Project  []string
+	Status   []string
+	Assignee []string
+	Type     []string
+	Version  []string
+	Label    []string
+	OrderBy  []string
+}
+
+// jqlFieldPattern matches field names which don't need quotes in JQL
+var jqlFieldPattern = regexp.MustCompile(`^[A-Za-z0-9_.]+$|^cf\[\d+\]$`)
+
+// QuoteJQL returns JQL string literal of value with quotes and backslashes escaped
+func QuoteJQL(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// Build method returns JQL of query. Conditions are joined with AND, ORDER BY of JQL is replaced by OrderBy when set.
+// Assignee "me" is replaced with currentUser() function. Saved filter is not resolved, see Client.SearchJQL
+func (q SearchQuery) Build() string {
+	where, orderBy := splitOrderBy(q.JQL)
+	clauses := make([]string, 0)
+	if where != "" {
+		clauses = append(clauses, where)
+	}
+	assignees := make([]string, 0, len(q.Assignee))
+	for _, a := range q.Assignee {
+		if strings.EqualFold(a, "me") || strings.EqualFold(a, "currentUser()") {
+			assignees = append(assignees, "currentUser()")
+		} else {
+			assignees = append(assignees, QuoteJQL(a))
+		}
+	}
+	clauses = appendInClause(clauses, "project", quoteAll(q.Project))
+	clauses = appendInClause(clauses, "issuetype", quoteAll(q.Type))
+	clauses = appendInClause(clauses, "status", quoteAll(q.Status))
+	clauses = appendInClause(clauses, "assignee", assignees)
+	clauses = appendInClause(clauses, "fixVersion", quoteAll(q.Version))
+	clauses = appendInClause(clauses, "labels", quoteAll(q.Label))
+	if len(clauses) > 1 && where != "" {
+		clauses[0] = "(" + where + ")"
+	}
+	jql := strings.Join(clauses, " AND ")
+	if len(q.OrderBy) > 0 {
+		orderBy = orderByClause(q.OrderBy)
+	}
+	if orderBy != "" {
+		jql = strings.TrimSpace(jql + " ORDER BY " + orderBy)
+	}
+	return jql
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, QuoteJQL(v))
+	}
+	return quoted
+}
+
+func appendInClause(clauses []string, field string, values []string) []string {
+	if len(values) == 0 {
+		return clauses
+	}
+	return append(clauses, fmt.Sprintf("%s in (%s)", field, strings.Join(values, ", ")))
+}
+
+// orderByClause returns ORDER BY fields, e.g. "created DESC" for "-created" or "created desc".
+// Field names with spaces are quoted
+func orderByClause(fields []string) string {
+	items := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		direction := ""
+		if strings.HasPrefix(f, "-") {
+			f, direction = strings.TrimSpace(f[1:]), "DESC"
+		} else if i := strings.LastIndex(f, " "); i > 0 {
+			switch d := strings.ToUpper(f[i+1:]); d {
+			case "ASC", "DESC":
+				f, direction = strings.TrimSpace(f[:i]), d
+			}
+		}
+		if !jqlFieldPattern.MatchString(f) {
+			f = QuoteJQL(f)
+		}
+		items = append(items, strings.TrimSpace(f+" "+direction))
+	}
+	return strings.Join(items, ", ")
+}
+
+// orderByPattern matches ORDER BY keyword of JQL
+var orderByPattern = regexp.MustCompile(`(?i)^order\s+by\b`)
+
+// splitOrderBy returns conditions and ORDER BY fields of JQL. Keywords in quoted strings are ignored
+func splitOrderBy(jql string) (string, string) {
+	var quote rune
+	escaped := false
+	for i, r := range jql {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0 && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case (i == 0 || jql[i-1] == ' ' || jql[i-1] == ')') && orderByPattern.MatchString(jql[i:]):
+			match := orderByPattern.FindString(jql[i:])
+			return strings.TrimSpace(jql[:i]), strings.TrimSpace(jql[i+len(match):])
+		}
+	}
+	return strings.TrimSpace(jql), ""
+}
+
+// joinJQL returns conditions of both queries joined with AND. ORDER BY of second query takes precedence
+func joinJQL(first string, second string) string {
+	firstWhere, firstOrder := splitOrderBy(first)
+	secondWhere, secondOrder := splitOrderBy(second)
+	where := firstWhere
+	if firstWhere != "" && secondWhere != "" {
+		where = fmt.Sprintf("(%s) AND (%s)", firstWhere, secondWhere)
+	} else if secondWhere != "" {
+		where = secondWhere
+	}
+	orderBy := secondOrder
+	if orderBy == "" {
+		orderBy = firstOrder
+	}
+	if orderBy != "" {
+		return strings.TrimSpace(where + " ORDER BY " + orderBy)
+	}
+	return where
+}
+
+// SearchJQL method returns JQL of query with JQL of saved filter joined to it
+func (c *Client) SearchJQL(ctx context.Context, q SearchQuery) (string, error) {
+	if q.Filter != "" {
+		filter, err := c.GetFilter(ctx, q.Filter)
+		if err != nil {
+			return "", err
+		}
+		q.JQL = joinJQL(filter.Jql, q.JQL)
+	}
+	return q.Build(), nil
+}
+
+// Search method returns issues found with query with given fields. Limit caps number of issues, zero means no limit
+func (c *Client) Search(ctx context.Context, q SearchQuery, fields []string, limit int) (models.IssueList, error) {
+	jql, err := c.SearchJQL(ctx, q)
+	if err != nil {
+		return models.IssueList{}, err
+	}
+	return c.SearchIssues(ctx, jql, fields, limit)
+}
+
+// GetFilter method returns saved filter with given id or name. Names are matched case insensitive
+// among filters visible to user on JIRA Cloud and favourite filters of user on JIRA Server
+func (c *Client) GetFilter(ctx context.Context, idOrName string) (models.Filter, error) {
+	filter := models.Filter{}
+	if _, err := strconv.Atoi(idOrName); err == nil {
+		_, err := c.execute(ctx, resty.MethodGet, fmt.Sprintf("rest/api/2/filter/%s", idOrName), nil, &filter, "", nil)
+		return filter, err
+	}
+	filters, err := c.searchFilters(ctx, idOrName)
+	var apiError *APIError
+	if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+		filters = make([]models.Filter, 0)
+		_, err = c.execute(ctx, resty.MethodGet, "rest/api/2/filter/favourite", nil, &filters, "", nil)
+	}
+	if err != nil {
+		return filter, err
+	}
+	matches := make([]models.Filter, 0)
+	for _, f := range filters {
+		if strings.EqualFold(f.Name, idOrName) {
+			matches = append(matches, f)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return filter, fmt.Errorf("unknown filter: %s", idOrName)
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, 0, len(matches))
+	for _, f := range matches {
+		ids = append(ids, f.Id)
+	}
+	return filter, fmt.Errorf("filter name %s is ambiguous, use one of ids: %s", idOrName, strings.Join(ids, ", "))
+}
+
+// searchFilters method returns filters with name containing given text. Endpoint is available on JIRA Cloud only
+func (c *Client) searchFilters(ctx context.Context, name string) ([]models.Filter, error) {
+	query := url.Values{}
+	query.Set("filterName", name)
+	query.Set("expand", "jql")
+	filters := make([]models.Filter, 0)
+	it := c.NewPageIterator(ctx, "rest/api/2/filter/search", query, "values", 0)
+	for it.Next() {
+		page := make([]models.Filter, 0)
+		if err := it.Decode(&page); err != nil {
+			return filters, err
+		}
+		filters = append(filters, page...)
+	}
+	return filters, it.Err()
+}
@@ -0,0 +1,103 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"gopkg.in/jarcoal/httpmock.v1"
+	"gotest.tools/assert"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestSearchQueryBuild(t *testing.T) {
+	tests := []struct {
+		query    SearchQuery
+		expected string
+	}{
+		{SearchQuery{JQL: "project = TEST"}, "project = TEST"},
+		{SearchQuery{Project: []string{"TEST"}, Status: []string{"In Progress", "To Do"}},
+			`project in ("TEST") AND status in ("In Progress", "To Do")`},
+		{SearchQuery{Label: []string{`a" OR labels != "b`}}, `labels in ("a\" OR labels != \"b")`},
+		{SearchQuery{Assignee: []string{"me", "john"}, Type: []string{"Bug"}},
+			`issuetype in ("Bug") AND assignee in (currentUser(), "john")`},
+		{SearchQuery{JQL: "status = Done OR status = Closed ORDER BY key", Version: []string{"1.0.0"}},
+			`(status = Done OR status = Closed) AND fixVersion in ("1.0.0") ORDER BY key`},
+		{SearchQuery{JQL: "summary ~ \"order by\" order by key", OrderBy: []string{"-created", "Story Points asc"}},
+			`summary ~ "order by" ORDER BY created DESC, "Story Points" ASC`},
+		{SearchQuery{OrderBy: []string{"priority desc"}}, "ORDER BY priority DESC"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.query.Build(), test.expected)
+	}
+}
+
+func TestGetFilter(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/filter/10000",
+		httpmock.NewStringResponder(200, readResponse("./responses/filter/10000.json")))
+	query := url.Values{"filterName": {"release"}, "expand": {"jql"}, "startAt": {"0"}, "maxResults": {"100"}}
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/filter/search?"+query.Encode(),
+		httpmock.NewStringResponder(200, readResponse("./responses/filter/search.json")))
+
+	filter, err := c.GetFilter(context.Background(), "10000")
+	assert.NilError(t, err)
+	assert.Equal(t, filter.Name, "Release")
+	filter, err = c.GetFilter(context.Background(), "release")
+	assert.NilError(t, err)
+	assert.Equal(t, filter.Id, "10000")
+
+	jql, err := c.SearchJQL(context.Background(), SearchQuery{Filter: "10000", JQL: "assignee = currentUser()", Type: []string{"Bug"}})
+	assert.NilError(t, err)
+	assert.Equal(t, jql, `((project = TEST AND status = "Ready for release") AND (assignee = currentUser())) AND issuetype in ("Bug") ORDER BY priority DESC`)
+}
+
+func TestGetFilterServer(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	for _, name := range []string{"my open issues", "unknown"} {
+		query := url.Values{"filterName": {name}, "expand": {"jql"}, "startAt": {"0"}, "maxResults": {"100"}}
+		httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/filter/search?"+query.Encode(),
+			httpmock.NewStringResponder(404, `{"errorMessages":["Not found"]}`))
+	}
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/filter/favourite",
+		httpmock.NewStringResponder(200, readResponse("./responses/filter/favourite.json")))
+
+	filter, err := c.GetFilter(context.Background(), "my open issues")
+	assert.NilError(t, err)
+	assert.Equal(t, filter.Id, "10002")
+	_, err = c.GetFilter(context.Background(), "unknown")
+	assert.Error(t, err, "unknown filter: unknown")
+}
+
+func TestGetIssuesInVersionsQuoted(t *testing.T) {
+	defer httpmock.DeactivateAndReset()
+	httpmock.Activate()
+	c := NewClient("https://jira.example.com", "user", "pass")
+	var jql string
+	httpmock.RegisterResponder("GET", "https://jira.example.com/rest/api/2/search",
+		func(req *http.Request) (*http.Response, error) {
+			jql = req.URL.Query().Get("jql")
+			return httpmock.NewStringResponse(200, readResponse("./responses/version/list.json")), nil
+		})
+
+	_, err := c.GetIssuesInVersions(context.Background(), "TEST", `1.0 beta") or project = "OTHER`, "Story, Bug", 0)
+	assert.NilError(t, err)
+	assert.Equal(t, jql, `project in ("TEST") AND issuetype in ("Story", "Bug") AND fixVersion in ("1.0 beta\") or project = \"OTHER")`)
+}
@@ -0,0 +1,198 @@
+// Copyright © 2019 Robert Sotomski <sotomski@gmail.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiraApi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertificate generates self signed certificate and writes it with its key to PEM files in dir
+func writeCertificate(t *testing.T, dir string, name string) (tls.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	if err := ioutil.WriteFile(certFile, certPem, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, keyPem, 0600); err != nil {
+		t.Fatal(err)
+	}
+	cert, err := tls.X509KeyPair(certPem, keyPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, certFile, keyFile
+}
+
+// writeServerCA writes certificate of test TLS server to PEM file
+func writeServerCA(t *testing.T, dir string, server *httptest.Server) string {
+	file := filepath.Join(dir, "server-ca.crt")
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := ioutil.WriteFile(file, certPem, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func issueHandler(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(readResponse("./responses/issue/TEST-1.json")))
+}
+
+func newTransportTestClient(t *testing.T, serverUrl string, config TransportConfig) *Client {
+	transport, err := NewTransport(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(serverUrl, "user", "pass", WithTransport(transport), WithRetryPolicy(RetryPolicy{}))
+}
+
+func TestTransportCACertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jira-cli-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	server := httptest.NewTLSServer(http.HandlerFunc(issueHandler))
+	defer server.Close()
+
+	_, err = newTransportTestClient(t, server.URL, TransportConfig{}).GetIssue(context.Background(), "TEST-1")
+	assert.ErrorContains(t, err, "certificate")
+
+	caFile := writeServerCA(t, dir, server)
+	issue, err := newTransportTestClient(t, server.URL, TransportConfig{CACertFiles: []string{caFile}}).GetIssue(context.Background(), "TEST-1")
+	assert.NilError(t, err)
+	assert.Equal(t, issue.Key, "TEST-1")
+
+	issue, err = newTransportTestClient(t, server.URL, TransportConfig{InsecureSkipVerify: true}).GetIssue(context.Background(), "TEST-1")
+	assert.NilError(t, err)
+	assert.Equal(t, issue.Key, "TEST-1")
+}
+
+func TestTransportClientCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jira-cli-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	clientCert, certFile, keyFile := writeCertificate(t, dir, "client")
+	leaf, err := x509.ParseCertificate(clientCert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(leaf)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(issueHandler))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+	caFile := writeServerCA(t, dir, server)
+
+	_, err = newTransportTestClient(t, server.URL, TransportConfig{CACertFiles: []string{caFile}}).GetIssue(context.Background(), "TEST-1")
+	assert.Assert(t, err != nil, "request without client certificate should fail")
+
+	config := TransportConfig{CACertFiles: []string{caFile}, ClientCertFile: certFile, ClientKeyFile: keyFile}
+	issue, err := newTransportTestClient(t, server.URL, config).GetIssue(context.Background(), "TEST-1")
+	assert.NilError(t, err)
+	assert.Equal(t, issue.Key, "TEST-1")
+}
+
+func TestTransportProxy(t *testing.T) {
+	proxied := make([]string, 0)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		issueHandler(w, r)
+	}))
+	defer proxy.Close()
+
+	c := newTransportTestClient(t, "http://jira.example.com", TransportConfig{Proxy: proxy.URL})
+	issue, err := c.GetIssue(context.Background(), "TEST-1")
+	assert.NilError(t, err)
+	assert.Equal(t, issue.Key, "TEST-1")
+	assert.DeepEqual(t, proxied, []string{"http://jira.example.com/rest/api/2/issue/TEST-1"})
+}
+
+func TestTransportConfigErrors(t *testing.T) {
+	_, err := NewTransport(TransportConfig{Proxy: "ftp://proxy"})
+	assert.ErrorContains(t, err, "scheme must be http, https or socks5")
+	_, err = NewTransport(TransportConfig{ClientCertFile: "client.crt"})
+	assert.Error(t, err, "both client certificate and client key must be set")
+	_, err = NewTransport(TransportConfig{CACertFiles: []string{"./responses/issue/TEST-1.json"}})
+	assert.Error(t, err, "no PEM certificates found in ./responses/issue/TEST-1.json")
+}
+
+func TestReadWorkflowTransport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jira-cli-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	authorization := make([]string, 0)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		w.Write([]byte(readResponse("./responses/workflow.yaml")))
+	}))
+	defer server.Close()
+
+	_, err = newTransportTestClient(t, "https://jira.example.com", TransportConfig{}).ReadWorkflow(context.Background(), server.URL+"/workflow.yaml")
+	assert.ErrorContains(t, err, "certificate")
+
+	caFile := writeServerCA(t, dir, server)
+	c := newTransportTestClient(t, "https://jira.example.com", TransportConfig{CACertFiles: []string{caFile}})
+	workflow, err := c.ReadWorkflow(context.Background(), server.URL+"/workflow.yaml")
+	assert.NilError(t, err)
+	transition, err := workflow.GetOrDefault("to do", "in progress")
+	assert.NilError(t, err)
+	assert.Equal(t, transition, "start progress")
+	assert.DeepEqual(t, authorization, []string{""})
+}
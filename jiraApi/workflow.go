@@ -2,6 +2,7 @@ package jiraApi
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/sirupsen/logrus"
@@ -16,22 +17,26 @@ type WorkflowTransitionsMap struct {
 	Workflow map[string]interface{}
 }
 
+// GetOrDefault method returns name of transition from current status towards target status.
+// It returns ErrNoPath when workflow doesn't define such transition
 func (workflow WorkflowTransitionsMap) GetOrDefault(currentStatus string, targetStatus string) (string, error) {
 	currentStatusTransitions := workflow.Workflow[currentStatus]
 	if currentStatusTransitions == nil {
-		return "", errors.New(fmt.Sprintf("Workflow does not define transitions for status: %s\n", currentStatus))
+		return "", fmt.Errorf("%w: workflow does not define transitions for status: %s", ErrNoPath, currentStatus)
 	}
-	if val, ok := cast.ToStringMap(cast.ToStringMap(currentStatusTransitions))[targetStatus]; ok {
-		return cast.ToString(val), nil
+	transitions := cast.ToStringMapString(currentStatusTransitions)
+	if val, ok := transitions[targetStatus]; ok {
+		return val, nil
 	}
-	if val, ok := currentStatusTransitions.(map[string]string)["default"]; ok {
-		return cast.ToString(val), nil
+	if val, ok := transitions["default"]; ok {
+		return val, nil
 	}
-	return "", errors.New(fmt.Sprintf("transition '%s' is not defined in Workflow\n", targetStatus))
+	return "", fmt.Errorf("%w: transition to '%s' is not defined in workflow", ErrNoPath, targetStatus)
 }
 
-// ReadWorkflow method loads Workflow definition from env var, http url or file
-func ReadWorkflow(workflowPath string) (WorkflowTransitionsMap, error) {
+// ReadWorkflow method loads Workflow definition from env var, http url or file. Workflow url is fetched with transport
+// of client, so proxy and TLS settings apply, but credentials of JIRA server are not sent
+func (c *Client) ReadWorkflow(ctx context.Context, workflowPath string) (WorkflowTransitionsMap, error) {
 	workflowContent := viper.GetString("JIRA_WORKFLOW_CONTENT")
 	if workflowContent != "" {
 		err := viper.MergeConfig(bytes.NewBuffer([]byte(workflowContent)))
@@ -41,13 +46,20 @@ func ReadWorkflow(workflowPath string) (WorkflowTransitionsMap, error) {
 		return WorkflowTransitionsMap{viper.GetStringMap("Workflow")}, nil
 	}
 	if strings.HasPrefix(workflowPath, "http://") || strings.HasPrefix(workflowPath, "https://") {
-		response, err := resty.New().R().Get(workflowPath)
-		logrus.Debugf("%#v\n", response)
+		response, err := resty.NewWithClient(c.fetchClient).SetTimeout(c.timeout).R().SetContext(ctx).Get(workflowPath)
 		if err != nil {
-			return WorkflowTransitionsMap{}, errors.New(fmt.Sprintf("%s %s", response.Body(), err))
+			return WorkflowTransitionsMap{}, fmt.Errorf("cannot fetch workflow %s: %w", workflowPath, err)
 		}
-		viper.MergeConfig(bytes.NewBuffer(response.Body()))
-		return WorkflowTransitionsMap{viper.GetStringMap("Workflow")}, nil
+		logrus.Debugf("GET: %s Response: %d %s\n", workflowPath, response.StatusCode(), string(response.Body()))
+		if response.IsError() {
+			return WorkflowTransitionsMap{}, fmt.Errorf("cannot fetch workflow %s: http error: %d", workflowPath, response.StatusCode())
+		}
+		content := viper.New()
+		content.SetConfigType("yaml")
+		if err := content.ReadConfig(bytes.NewBuffer(response.Body())); err != nil {
+			return WorkflowTransitionsMap{}, err
+		}
+		return WorkflowTransitionsMap{content.GetStringMap("Workflow")}, nil
 	}
 	if _, err := os.Stat(workflowPath); err != nil {
 		if os.IsNotExist(err) {